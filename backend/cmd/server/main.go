@@ -37,8 +37,11 @@ func main() {
 		log.Fatalf("Failed to run database migrations: %v", err)
 	}
 
-	// Setup Gin router
-	router := setupRouter()
+	// Setup Gin router and the uptime monitor behind it
+	router, monitorService := setupRouter()
+
+	// Start probing monitored URLs in the background
+	monitorService.Start()
 
 	// Get port from environment
 	port := getEnv("PORT", "8080")
@@ -72,6 +75,9 @@ func main() {
 		log.Fatal("Server forced to shutdown: ", err)
 	}
 
+	// Stop probing before the database connection closes
+	monitorService.Stop()
+
 	// Close database connection
 	if err := database.CloseDatabase(); err != nil {
 		log.Printf("Error closing database: %v", err)
@@ -80,7 +86,7 @@ func main() {
 	log.Println("Server exited")
 }
 
-func setupRouter() *gin.Engine {
+func setupRouter() (*gin.Engine, *services.MonitorService) {
 	// Set Gin mode
 	gin.SetMode(getEnv("GIN_MODE", "debug"))
 	
@@ -98,12 +104,12 @@ func setupRouter() *gin.Engine {
 	}))
 
 	// Setup routes
-	setupRoutes(router)
+	monitorService := setupRoutes(router)
 
-	return router
+	return router, monitorService
 }
 
-func setupRoutes(router *gin.Engine) {
+func setupRoutes(router *gin.Engine) *services.MonitorService {
 	// Initialize auth service
 	authService, err := auth.NewAuthService()
 	if err != nil {
@@ -112,10 +118,25 @@ func setupRoutes(router *gin.Engine) {
 
 	// Initialize services
 	urlService := services.NewURLService(database.GetDB())
+	linkFilterService := services.NewLinkFilterService(database.GetDB())
+	linkGraphService := services.NewLinkGraphService(database.GetDB())
+	duplicateService := services.NewDuplicateService(database.GetDB())
+	analyzerService := services.NewAnalyzerService(database.GetDB())
+	extractionService := services.NewExtractionService(database.GetDB())
+	assertionService := services.NewAssertionService(database.GetDB())
+	monitorService := services.NewMonitorService(database.GetDB(), urlService.CrawlerService())
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(database.GetDB(), authService)
 	urlHandler := handlers.NewURLHandler(database.GetDB(), urlService)
+	linkFilterHandler := handlers.NewLinkFilterHandler(linkFilterService)
+	linkGraphHandler := handlers.NewLinkGraphHandler(linkGraphService)
+	duplicateHandler := handlers.NewDuplicateHandler(duplicateService)
+	analyzerHandler := handlers.NewAnalyzerHandler(analyzerService)
+	extractionHandler := handlers.NewExtractionHandler(extractionService)
+	assertionHandler := handlers.NewAssertionHandler(assertionService)
+	monitorHandler := handlers.NewMonitorHandler(monitorService)
+	metricsHandler := handlers.NewMetricsHandler(urlService)
 
 	// API group
 	api := router.Group("/api")
@@ -123,6 +144,9 @@ func setupRoutes(router *gin.Engine) {
 	// Health check endpoint
 	api.GET("/health", healthCheck)
 
+	// Crawler metrics (circuit breakers and throttled hosts of the user's URLs, link cache)
+	api.GET("/metrics", middleware.AuthMiddleware(authService), metricsHandler.GetMetrics)
+
 	// Authentication routes
 	authRoutes := api.Group("/auth")
 	{
@@ -146,9 +170,83 @@ func setupRoutes(router *gin.Engine) {
 		urlRoutes.POST("/:id/stop", urlHandler.StopAnalysis)
 		urlRoutes.POST("/:id/rerun", urlHandler.ReRunAnalysis)
 		urlRoutes.GET("/:id/result", urlHandler.GetAnalysisResult)
+
+		// SEO rule configuration
+		urlRoutes.GET("/:id/seo/rules", urlHandler.GetSEORules)
+		urlRoutes.PUT("/:id/seo/rules", urlHandler.UpdateSEORules)
+
+		// Crawl settings
+		urlRoutes.GET("/:id/settings", urlHandler.GetCrawlSettings)
+		urlRoutes.PUT("/:id/settings", urlHandler.UpdateCrawlSettings)
+
+		// Assertions checked after each crawl and their runs
+		urlRoutes.GET("/:id/assertions", assertionHandler.ListAssertions)
+		urlRoutes.POST("/:id/assertions", assertionHandler.CreateAssertion)
+		urlRoutes.PUT("/:id/assertions/:assertion_id", assertionHandler.UpdateAssertion)
+		urlRoutes.DELETE("/:id/assertions/:assertion_id", assertionHandler.DeleteAssertion)
+		urlRoutes.GET("/:id/assertions/runs", assertionHandler.ListRuns)
+
+		// Uptime monitoring, which only fetches the page at a short interval
+		urlRoutes.GET("/:id/monitor", monitorHandler.GetMonitor)
+		urlRoutes.PUT("/:id/monitor", monitorHandler.UpdateMonitor)
+		urlRoutes.GET("/:id/uptime", monitorHandler.GetUptime)
+		urlRoutes.GET("/:id/uptime/checks", monitorHandler.GetChecks)
+
+		// Link graph of a page
+		urlRoutes.GET("/:id/links/outbound", linkGraphHandler.GetOutboundLinks)
+		urlRoutes.GET("/:id/links/inbound", linkGraphHandler.GetInboundLinksForURL)
+		urlRoutes.GET("/:id/graph", linkGraphHandler.ExportGraph)
+	}
+
+	// Protected link checker filter rules
+	linkFilterRoutes := api.Group("/link-filters")
+	linkFilterRoutes.Use(middleware.AuthMiddleware(authService))
+	{
+		linkFilterRoutes.GET("", linkFilterHandler.ListRules)
+		linkFilterRoutes.POST("", linkFilterHandler.CreateRule)
+		linkFilterRoutes.PUT("/:id", linkFilterHandler.UpdateRule)
+		linkFilterRoutes.DELETE("/:id", linkFilterHandler.DeleteRule)
+	}
+
+	// Protected extraction rules, whose values are stored with each analysis
+	extractionRoutes := api.Group("/extraction-rules")
+	extractionRoutes.Use(middleware.AuthMiddleware(authService))
+	{
+		extractionRoutes.GET("", extractionHandler.ListRules)
+		extractionRoutes.POST("", extractionHandler.CreateRule)
+		extractionRoutes.PUT("/:id", extractionHandler.UpdateRule)
+		extractionRoutes.DELETE("/:id", extractionHandler.DeleteRule)
+	}
+
+	// Protected link graph routes across the user's pages
+	linkRoutes := api.Group("/links")
+	linkRoutes.Use(middleware.AuthMiddleware(authService))
+	{
+		linkRoutes.GET("/inbound", linkGraphHandler.GetInboundLinks)
+		linkRoutes.GET("/top", linkGraphHandler.GetTopTargets)
+		linkRoutes.GET("/orphans", linkGraphHandler.GetOrphanPages)
+	}
+
+	// Protected page analyzer settings
+	analyzerRoutes := api.Group("/analyzers")
+	analyzerRoutes.Use(middleware.AuthMiddleware(authService))
+	{
+		analyzerRoutes.GET("", analyzerHandler.ListAnalyzers)
+		analyzerRoutes.PUT("", analyzerHandler.UpdateAnalyzers)
 	}
 
+	// Near-duplicate content and duplicate titles and meta descriptions across the user's pages
+	api.GET("/duplicates", middleware.AuthMiddleware(authService), duplicateHandler.GetDuplicates)
+
+	// Technologies detected across the user's pages
+	api.GET("/technologies", middleware.AuthMiddleware(authService), urlHandler.GetTechnologies)
+
+	// Third-party and tracker inventory across the user's pages
+	api.GET("/trackers", middleware.AuthMiddleware(authService), urlHandler.GetTrackers)
+
 	log.Println("Routes initialized successfully with crawler integration")
+
+	return monitorService
 }
 
 func healthCheck(c *gin.Context) {
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"web-crawler-dashboard/internal/api/middleware"
+	"web-crawler-dashboard/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AnalyzerHandler struct {
+	analyzerService *services.AnalyzerService
+}
+
+// UpdateAnalyzersRequest represents the request body for toggling page analyzers
+type UpdateAnalyzersRequest struct {
+	Analyzers map[string]bool `json:"analyzers" binding:"required"`
+}
+
+// NewAnalyzerHandler creates a new analyzer handler
+func NewAnalyzerHandler(analyzerService *services.AnalyzerService) *AnalyzerHandler {
+	return &AnalyzerHandler{
+		analyzerService: analyzerService,
+	}
+}
+
+// ListAnalyzers lists the registered page analyzers and whether they run for the user
+func (h *AnalyzerHandler) ListAnalyzers(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	analyzers, err := h.analyzerService.ListAnalyzers(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "service_error",
+			"message": "Failed to retrieve analyzers",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"analyzers": analyzers})
+}
+
+// UpdateAnalyzers enables or disables page analyzers for the user's future analyses
+func (h *AnalyzerHandler) UpdateAnalyzers(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	var req UpdateAnalyzersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_failed",
+			"message": "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.analyzerService.UpdateAnalyzers(userID, req.Analyzers); err != nil {
+		if strings.Contains(err.Error(), "unknown analyzer") {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_analyzer",
+				"message": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "service_error",
+			"message": "Failed to update analyzers",
+		})
+		return
+	}
+
+	analyzers, err := h.analyzerService.ListAnalyzers(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "service_error",
+			"message": "Failed to retrieve analyzers",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"analyzers": analyzers})
+}
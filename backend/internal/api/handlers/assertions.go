@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"web-crawler-dashboard/internal/api/middleware"
+	"web-crawler-dashboard/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssertionHandler struct {
+	assertionService *services.AssertionService
+}
+
+// NewAssertionHandler creates a new assertion handler
+func NewAssertionHandler(assertionService *services.AssertionService) *AssertionHandler {
+	return &AssertionHandler{
+		assertionService: assertionService,
+	}
+}
+
+// AssertionRequest represents the request body for creating or updating an assertion
+type AssertionRequest struct {
+	Type  string `json:"type" binding:"required,oneof=status_code contains_text not_contains_text selector_exists max_response_time min_links title_matches"`
+	Value string `json:"value" binding:"required,max=1000"`
+}
+
+// ListAssertions lists the assertions of a URL
+func (h *AssertionHandler) ListAssertions(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get URL ID from params
+	urlID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	assertions, err := h.assertionService.ListAssertions(userID, uint(urlID))
+	if err != nil {
+		h.handleAssertionError(c, err, "Failed to retrieve assertions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"assertions": assertions})
+}
+
+// CreateAssertion adds an assertion to a URL
+func (h *AssertionHandler) CreateAssertion(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get URL ID from params
+	urlID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	var req AssertionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_failed",
+			"message": "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	assertion, err := h.assertionService.CreateAssertion(userID, uint(urlID), req.Type, req.Value)
+	if err != nil {
+		h.handleAssertionError(c, err, "Failed to create assertion")
+		return
+	}
+
+	c.JSON(http.StatusCreated, assertion)
+}
+
+// UpdateAssertion replaces an assertion of a URL
+func (h *AssertionHandler) UpdateAssertion(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get URL and assertion IDs from params
+	urlID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+	assertionID, err := strconv.ParseUint(c.Param("assertion_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid assertion ID",
+		})
+		return
+	}
+
+	var req AssertionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_failed",
+			"message": "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	assertion, err := h.assertionService.UpdateAssertion(userID, uint(urlID), uint(assertionID), req.Type, req.Value)
+	if err != nil {
+		h.handleAssertionError(c, err, "Failed to update assertion")
+		return
+	}
+
+	c.JSON(http.StatusOK, assertion)
+}
+
+// DeleteAssertion removes an assertion from a URL
+func (h *AssertionHandler) DeleteAssertion(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get URL and assertion IDs from params
+	urlID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+	assertionID, err := strconv.ParseUint(c.Param("assertion_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid assertion ID",
+		})
+		return
+	}
+
+	if err := h.assertionService.DeleteAssertion(userID, uint(urlID), uint(assertionID)); err != nil {
+		h.handleAssertionError(c, err, "Failed to delete assertion")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Assertion deleted successfully",
+	})
+}
+
+// ListRuns lists the most recent assertion runs of a URL with their results
+func (h *AssertionHandler) ListRuns(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get URL ID from params
+	urlID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	runs, err := h.assertionService.ListRuns(userID, uint(urlID), limit)
+	if err != nil {
+		h.handleAssertionError(c, err, "Failed to retrieve assertion runs")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"runs": runs})
+}
+
+// handleAssertionError maps service errors to HTTP responses
+func (h *AssertionHandler) handleAssertionError(c *gin.Context, err error, message string) {
+	if strings.Contains(err.Error(), "URL not found") {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "url_not_found",
+			"message": err.Error(),
+		})
+		return
+	}
+	if strings.Contains(err.Error(), "not found") {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "assertion_not_found",
+			"message": err.Error(),
+		})
+		return
+	}
+	if strings.Contains(err.Error(), "invalid") {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_assertion",
+			"message": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error":   "service_error",
+		"message": message,
+	})
+}
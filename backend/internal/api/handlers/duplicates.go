@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"web-crawler-dashboard/internal/api/middleware"
+	"web-crawler-dashboard/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DuplicateHandler struct {
+	duplicateService *services.DuplicateService
+}
+
+// NewDuplicateHandler creates a new duplicate handler
+func NewDuplicateHandler(duplicateService *services.DuplicateService) *DuplicateHandler {
+	return &DuplicateHandler{
+		duplicateService: duplicateService,
+	}
+}
+
+// GetDuplicates groups the user's analyzed pages into near-duplicate clusters (similarity
+// at or above ?threshold, 0.85 by default) and lists duplicate titles and meta descriptions
+func (h *DuplicateHandler) GetDuplicates(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	threshold := services.DefaultDuplicateThreshold
+	if rawThreshold := c.Query("threshold"); rawThreshold != "" {
+		parsed, err := strconv.ParseFloat(rawThreshold, 64)
+		if err != nil || parsed <= 0 || parsed > 1 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_threshold",
+				"message": "Threshold must be a number between 0 and 1",
+			})
+			return
+		}
+		threshold = parsed
+	}
+
+	report, err := h.duplicateService.FindDuplicates(userID, threshold)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "service_error",
+			"message": "Failed to find duplicate pages",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
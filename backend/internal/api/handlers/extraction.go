@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"web-crawler-dashboard/internal/api/middleware"
+	"web-crawler-dashboard/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExtractionHandler struct {
+	extractionService *services.ExtractionService
+}
+
+// NewExtractionHandler creates a new extraction handler
+func NewExtractionHandler(extractionService *services.ExtractionService) *ExtractionHandler {
+	return &ExtractionHandler{
+		extractionService: extractionService,
+	}
+}
+
+// ExtractionRuleRequest represents the request body for creating or updating an extraction rule
+type ExtractionRuleRequest struct {
+	URLID       *uint  `json:"url_id"`
+	Name        string `json:"name" binding:"required,max=100"`
+	Type        string `json:"type" binding:"required,oneof=css regex"`
+	Expression  string `json:"expression" binding:"required,max=1000"`
+	Attribute   string `json:"attribute" binding:"max=100"`
+	Mode        string `json:"mode" binding:"omitempty,oneof=single list"`
+	HostSuffix  string `json:"host_suffix" binding:"max=255"`
+	PathPattern string `json:"path_pattern" binding:"max=500"`
+}
+
+// toInput converts the request to service input
+func (r ExtractionRuleRequest) toInput() services.ExtractionRuleInput {
+	return services.ExtractionRuleInput{
+		URLID:       r.URLID,
+		Name:        r.Name,
+		Type:        r.Type,
+		Expression:  r.Expression,
+		Attribute:   r.Attribute,
+		Mode:        r.Mode,
+		HostSuffix:  r.HostSuffix,
+		PathPattern: r.PathPattern,
+	}
+}
+
+// ListRules lists the user's extraction rules, optionally for a single URL
+func (h *ExtractionHandler) ListRules(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	var urlID *uint
+	if rawURLID := c.Query("url_id"); rawURLID != "" {
+		parsed, err := strconv.ParseUint(rawURLID, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_id",
+				"message": "Invalid URL ID",
+			})
+			return
+		}
+		id := uint(parsed)
+		urlID = &id
+	}
+
+	rules, err := h.extractionService.ListRules(userID, urlID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "service_error",
+			"message": "Failed to retrieve extraction rules",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rules": rules})
+}
+
+// CreateRule adds an extraction rule
+func (h *ExtractionHandler) CreateRule(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	var req ExtractionRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_failed",
+			"message": "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.extractionService.CreateRule(userID, req.toInput())
+	if err != nil {
+		h.handleRuleError(c, err, "Failed to create extraction rule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// UpdateRule replaces an extraction rule
+func (h *ExtractionHandler) UpdateRule(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get rule ID from params
+	ruleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid rule ID",
+		})
+		return
+	}
+
+	var req ExtractionRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_failed",
+			"message": "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.extractionService.UpdateRule(userID, uint(ruleID), req.toInput())
+	if err != nil {
+		h.handleRuleError(c, err, "Failed to update extraction rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeleteRule removes an extraction rule
+func (h *ExtractionHandler) DeleteRule(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get rule ID from params
+	ruleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid rule ID",
+		})
+		return
+	}
+
+	if err := h.extractionService.DeleteRule(userID, uint(ruleID)); err != nil {
+		h.handleRuleError(c, err, "Failed to delete extraction rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Extraction rule deleted successfully",
+	})
+}
+
+// handleRuleError maps service errors to HTTP responses
+func (h *ExtractionHandler) handleRuleError(c *gin.Context, err error, message string) {
+	if strings.Contains(err.Error(), "URL not found") {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "url_not_found",
+			"message": err.Error(),
+		})
+		return
+	}
+	if strings.Contains(err.Error(), "not found") {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "rule_not_found",
+			"message": err.Error(),
+		})
+		return
+	}
+	if strings.Contains(err.Error(), "invalid") {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_rule",
+			"message": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error":   "service_error",
+		"message": message,
+	})
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"web-crawler-dashboard/internal/api/middleware"
+	"web-crawler-dashboard/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LinkFilterHandler struct {
+	linkFilterService *services.LinkFilterService
+}
+
+// NewLinkFilterHandler creates a new link filter handler
+func NewLinkFilterHandler(linkFilterService *services.LinkFilterService) *LinkFilterHandler {
+	return &LinkFilterHandler{
+		linkFilterService: linkFilterService,
+	}
+}
+
+// LinkFilterRuleRequest represents the request body for creating or updating a link filter rule
+type LinkFilterRuleRequest struct {
+	URLID       *uint  `json:"url_id"`
+	Action      string `json:"action" binding:"required,oneof=include exclude"`
+	HostSuffix  string `json:"host_suffix"`
+	PathPattern string `json:"path_pattern"`
+	PatternType string `json:"pattern_type" binding:"omitempty,oneof=glob regex"`
+	Reason      string `json:"reason"`
+}
+
+// toInput converts the request to service input
+func (r LinkFilterRuleRequest) toInput() services.LinkFilterRuleInput {
+	return services.LinkFilterRuleInput{
+		URLID:       r.URLID,
+		Action:      r.Action,
+		HostSuffix:  r.HostSuffix,
+		PathPattern: r.PathPattern,
+		PatternType: r.PatternType,
+		Reason:      r.Reason,
+	}
+}
+
+// ListRules lists the user's link filter rules, optionally for a single URL
+func (h *LinkFilterHandler) ListRules(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	var urlID *uint
+	if rawURLID := c.Query("url_id"); rawURLID != "" {
+		parsed, err := strconv.ParseUint(rawURLID, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_id",
+				"message": "Invalid URL ID",
+			})
+			return
+		}
+		id := uint(parsed)
+		urlID = &id
+	}
+
+	rules, err := h.linkFilterService.ListRules(userID, urlID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "service_error",
+			"message": "Failed to retrieve link filter rules",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rules": rules})
+}
+
+// CreateRule adds a link filter rule
+func (h *LinkFilterHandler) CreateRule(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	var req LinkFilterRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_failed",
+			"message": "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.linkFilterService.CreateRule(userID, req.toInput())
+	if err != nil {
+		h.handleRuleError(c, err, "Failed to create link filter rule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// UpdateRule replaces a link filter rule
+func (h *LinkFilterHandler) UpdateRule(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get rule ID from params
+	ruleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid rule ID",
+		})
+		return
+	}
+
+	var req LinkFilterRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_failed",
+			"message": "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.linkFilterService.UpdateRule(userID, uint(ruleID), req.toInput())
+	if err != nil {
+		h.handleRuleError(c, err, "Failed to update link filter rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeleteRule removes a link filter rule
+func (h *LinkFilterHandler) DeleteRule(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get rule ID from params
+	ruleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid rule ID",
+		})
+		return
+	}
+
+	if err := h.linkFilterService.DeleteRule(userID, uint(ruleID)); err != nil {
+		h.handleRuleError(c, err, "Failed to delete link filter rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Link filter rule deleted successfully",
+	})
+}
+
+// handleRuleError maps service errors to HTTP responses
+func (h *LinkFilterHandler) handleRuleError(c *gin.Context, err error, message string) {
+	if strings.Contains(err.Error(), "URL not found") {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "url_not_found",
+			"message": err.Error(),
+		})
+		return
+	}
+	if strings.Contains(err.Error(), "not found") {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "rule_not_found",
+			"message": err.Error(),
+		})
+		return
+	}
+	if strings.Contains(err.Error(), "invalid") {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_rule",
+			"message": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error":   "service_error",
+		"message": message,
+	})
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"web-crawler-dashboard/internal/api/middleware"
+	"web-crawler-dashboard/internal/graph"
+	"web-crawler-dashboard/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LinkGraphHandler struct {
+	linkGraphService *services.LinkGraphService
+}
+
+// NewLinkGraphHandler creates a new link graph handler
+func NewLinkGraphHandler(linkGraphService *services.LinkGraphService) *LinkGraphHandler {
+	return &LinkGraphHandler{
+		linkGraphService: linkGraphService,
+	}
+}
+
+// GetOutboundLinks lists the links found on a URL
+func (h *LinkGraphHandler) GetOutboundLinks(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get URL ID from params
+	urlID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	links, err := h.linkGraphService.GetOutboundLinks(userID, uint(urlID))
+	if err != nil {
+		h.handleGraphError(c, err, "Failed to retrieve outbound links")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"links": links})
+}
+
+// GetInboundLinksForURL lists the user's pages linking to a URL
+func (h *LinkGraphHandler) GetInboundLinksForURL(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get URL ID from params
+	urlID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	links, err := h.linkGraphService.GetInboundLinksForURL(userID, uint(urlID))
+	if err != nil {
+		h.handleGraphError(c, err, "Failed to retrieve inbound links")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"links": links})
+}
+
+// GetInboundLinks lists the user's pages linking to any target, such as a broken link
+func (h *LinkGraphHandler) GetInboundLinks(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	target := strings.TrimSpace(c.Query("url"))
+	if target == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_failed",
+			"message": "The url query parameter is required",
+		})
+		return
+	}
+
+	links, err := h.linkGraphService.GetInboundLinks(userID, target)
+	if err != nil {
+		h.handleGraphError(c, err, "Failed to retrieve inbound links")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"links": links})
+}
+
+// GetTopTargets lists the most linked targets across the user's pages
+func (h *LinkGraphHandler) GetTopTargets(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	internalOnly := c.Query("internal") == "true"
+
+	targets, err := h.linkGraphService.GetTopTargets(userID, limit, internalOnly)
+	if err != nil {
+		h.handleGraphError(c, err, "Failed to retrieve top link targets")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"targets": targets})
+}
+
+// GetOrphanPages lists the user's analyzed pages that none of their other pages link to
+func (h *LinkGraphHandler) GetOrphanPages(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	pages, err := h.linkGraphService.GetOrphanPages(userID)
+	if err != nil {
+		h.handleGraphError(c, err, "Failed to retrieve orphan pages")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pages": pages})
+}
+
+// ExportGraph renders the link graph of a URL, or with scope=site of the whole crawled site,
+// as Graphviz DOT (format=dot), GraphML (format=graphml) or JSON node-link (format=json)
+func (h *LinkGraphHandler) ExportGraph(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get URL ID from params
+	urlID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	format := c.DefaultQuery("format", graph.FormatNodeLink)
+	if !graph.IsValidFormat(format) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_format",
+			"message": "Format must be one of dot, graphml or json",
+		})
+		return
+	}
+
+	scope := c.DefaultQuery("scope", "page")
+	if scope != "page" && scope != "site" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_scope",
+			"message": "Scope must be page or site",
+		})
+		return
+	}
+
+	g, err := h.linkGraphService.BuildGraph(userID, uint(urlID), scope == "site")
+	if err != nil {
+		h.handleGraphError(c, err, "Failed to build link graph")
+		return
+	}
+
+	if format == graph.FormatNodeLink {
+		c.JSON(http.StatusOK, graph.ToNodeLink(g))
+		return
+	}
+
+	var buf bytes.Buffer
+	contentType := "text/vnd.graphviz; charset=utf-8"
+	if format == graph.FormatGraphML {
+		contentType = "application/graphml+xml; charset=utf-8"
+		err = graph.WriteGraphML(&buf, g)
+	} else {
+		err = graph.WriteDOT(&buf, g)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "export_failed",
+			"message": "Failed to render link graph",
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="link-graph-%d-%s.%s"`, urlID, scope, format))
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// handleGraphError maps service errors to HTTP responses
+func (h *LinkGraphHandler) handleGraphError(c *gin.Context, err error, message string) {
+	if strings.Contains(err.Error(), "not found") {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "url_not_found",
+			"message": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error":   "service_error",
+		"message": message,
+	})
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+
+	"web-crawler-dashboard/internal/api/middleware"
+	"web-crawler-dashboard/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MetricsHandler struct {
+	urlService *services.URLService
+}
+
+// NewMetricsHandler creates a new metrics handler
+func NewMetricsHandler(urlService *services.URLService) *MetricsHandler {
+	return &MetricsHandler{
+		urlService: urlService,
+	}
+}
+
+// GetMetrics returns the crawler's running jobs and link cache size, and the circuit breakers
+// and throttled hosts among the hosts of the user's URLs
+func (h *MetricsHandler) GetMetrics(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	metrics, err := h.urlService.GetCrawlerMetrics(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "service_error",
+			"message": "Failed to retrieve crawler metrics",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"crawler": metrics,
+	})
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"web-crawler-dashboard/internal/api/middleware"
+	"web-crawler-dashboard/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MonitorHandler struct {
+	monitorService *services.MonitorService
+}
+
+// NewMonitorHandler creates a new monitor handler
+func NewMonitorHandler(monitorService *services.MonitorService) *MonitorHandler {
+	return &MonitorHandler{
+		monitorService: monitorService,
+	}
+}
+
+// UpdateMonitorRequest represents the request body for changing uptime monitoring settings
+type UpdateMonitorRequest struct {
+	Enabled         *bool `json:"enabled"`
+	IntervalSeconds *int  `json:"interval_seconds"`
+}
+
+// GetMonitor returns the uptime monitoring settings of a URL
+func (h *MonitorHandler) GetMonitor(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get URL ID from params
+	urlID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	monitor, err := h.monitorService.GetMonitor(userID, uint(urlID))
+	if err != nil {
+		h.handleMonitorError(c, err, "Failed to retrieve monitor")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"monitor": monitor})
+}
+
+// UpdateMonitor enables or disables uptime monitoring of a URL and changes its interval
+func (h *MonitorHandler) UpdateMonitor(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get URL ID from params
+	urlID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	var req UpdateMonitorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_failed",
+			"message": "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	monitor, err := h.monitorService.UpdateMonitor(userID, uint(urlID), services.MonitorUpdate{
+		Enabled:         req.Enabled,
+		IntervalSeconds: req.IntervalSeconds,
+	})
+	if err != nil {
+		h.handleMonitorError(c, err, "Failed to update monitor")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"monitor": monitor})
+}
+
+// GetUptime reports the uptime percentage, response times and incidents of a URL over
+// the last 24 hours, 7 and 30 days
+func (h *MonitorHandler) GetUptime(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get URL ID from params
+	urlID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	report, err := h.monitorService.GetUptime(userID, uint(urlID))
+	if err != nil {
+		h.handleMonitorError(c, err, "Failed to retrieve uptime")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetChecks returns the most recent uptime probes of a URL, oldest first
+func (h *MonitorHandler) GetChecks(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get URL ID from params
+	urlID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "1440"))
+
+	checks, err := h.monitorService.GetChecks(userID, uint(urlID), limit)
+	if err != nil {
+		h.handleMonitorError(c, err, "Failed to retrieve uptime checks")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"checks": checks})
+}
+
+// handleMonitorError maps service errors to HTTP responses
+func (h *MonitorHandler) handleMonitorError(c *gin.Context, err error, message string) {
+	if strings.Contains(err.Error(), "not found") {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "url_not_found",
+			"message": err.Error(),
+		})
+		return
+	}
+	if strings.Contains(err.Error(), "invalid interval") {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_interval",
+			"message": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error":   "service_error",
+		"message": message,
+	})
+}
@@ -59,6 +59,17 @@ type URLListResponse struct {
 	TotalPages int           `json:"total_pages"`
 }
 
+// UpdateSEORulesRequest represents the request body for toggling SEO rules
+type UpdateSEORulesRequest struct {
+	Rules map[string]bool `json:"rules" binding:"required"`
+}
+
+// UpdateCrawlSettingsRequest represents the request body for changing crawl settings
+type UpdateCrawlSettingsRequest struct {
+	DetectSoft404  *bool    `json:"detect_soft_404"`
+	CountedClasses []string `json:"counted_classes"`
+}
+
 // CreateURL creates a new URL for analysis
 func (h *URLHandler) CreateURL(c *gin.Context) {
 	var req CreateURLRequest
@@ -368,8 +379,9 @@ func (h *URLHandler) StartAnalysis(c *gin.Context) {
 		return
 	}
 
-	// Use service layer to start analysis
-	err = h.urlService.StartAnalysis(c.Request.Context(), userID, uint(urlID))
+	// Use service layer to start analysis; ?fresh=true bypasses the link check cache
+	fresh := c.Query("fresh") == "true"
+	err = h.urlService.StartAnalysis(c.Request.Context(), userID, uint(urlID), fresh)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -504,8 +516,9 @@ func (h *URLHandler) ReRunAnalysis(c *gin.Context) {
 		return
 	}
 
-	// Re-run analysis
-	err = h.urlService.ReRunAnalysis(c.Request.Context(), userID, uint(urlID))
+	// Re-run analysis; ?fresh=true bypasses the link check cache
+	fresh := c.Query("fresh") == "true"
+	err = h.urlService.ReRunAnalysis(c.Request.Context(), userID, uint(urlID), fresh)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -606,6 +619,14 @@ func (h *URLHandler) GetAnalysisResult(c *gin.Context) {
 		return
 	}
 
+	// Summarize broken references by resource type and outcome class
+	brokenByType := make(map[string]int)
+	brokenByClass := make(map[string]int)
+	for _, brokenLink := range analysis.BrokenLinksDetails {
+		brokenByType[brokenLink.ResourceType]++
+		brokenByClass[brokenLink.Class]++
+	}
+
 	// Create detailed response
 	response := map[string]interface{}{
 		"id":              url.ID,
@@ -626,10 +647,324 @@ func (h *URLHandler) GetAnalysisResult(c *gin.Context) {
 			"h6": analysis.H6Count,
 		},
 		"broken_links_details": analysis.BrokenLinksDetails,
+		"broken_by_type":       brokenByType,
+		"broken_by_class":      brokenByClass,
+		"skipped_links":        analysis.SkippedLinks,
+		"link_issues":          analysis.LinkIssues,
+		"canonical_url":        analysis.CanonicalURL,
+		"robots": map[string]interface{}{
+			"meta":         analysis.RobotsMeta,
+			"x_robots_tag": analysis.XRobotsTag,
+			"noindex":      analysis.NoIndex,
+			"nofollow":     analysis.NoFollow,
+		},
+		"hreflang_links":  analysis.HreflangLinks,
+		"indexing_issues": analysis.IndexingIssues,
+		"outline": map[string]interface{}{
+			"headings": analysis.Headings,
+			"issues":   analysis.HeadingIssues,
+		},
+		"images": map[string]interface{}{
+			"inventory": analysis.Images,
+			"issues":    analysis.ImageIssues,
+		},
+		"mixed_content": analysis.MixedContent,
+		"technologies":  analysis.Technologies,
+		"forms": map[string]interface{}{
+			"inventory": analysis.Forms,
+			"issues":    analysis.FormIssues,
+		},
+		"extractions": analysis.Extractions,
+		"analyzers": map[string]interface{}{
+			"metrics":  analysis.AnalyzerMetrics,
+			"findings": analysis.AnalyzerFindings,
+		},
+		"privacy": map[string]interface{}{
+			"consent_banner":          analysis.ConsentBanner,
+			"consent_provider":        analysis.ConsentProvider,
+			"trackers_before_consent": analysis.TrackersBeforeConsent,
+			"third_parties":           analysis.ThirdParties,
+		},
+		"content": map[string]interface{}{
+			"word_count":      analysis.WordCount,
+			"sentence_count":  analysis.SentenceCount,
+			"reading_ease":    analysis.ReadingEase,
+			"text_html_ratio": analysis.TextHTMLRatio,
+			"language":        analysis.Language,
+		},
+		"seo": map[string]interface{}{
+			"score":    analysis.SEOScore,
+			"findings": analysis.SEOFindings,
+		},
 		"created_at":           url.CreatedAt,
 		"updated_at":           url.UpdatedAt,
 		"analyzed_at":          analysis.AnalyzedAt,
 	}
 
 	c.JSON(http.StatusOK, response)
-} 
\ No newline at end of file
+}
+
+// GetSEORules lists the SEO rules and their enabled state for a URL
+func (h *URLHandler) GetSEORules(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get URL ID from params
+	urlID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	rules, err := h.urlService.GetSEORules(userID, uint(urlID))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "url_not_found",
+				"message": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "service_error",
+			"message": "Failed to retrieve SEO rules",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rules": rules})
+}
+
+// UpdateSEORules enables or disables SEO rules for a URL
+func (h *URLHandler) UpdateSEORules(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get URL ID from params
+	urlID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	var req UpdateSEORulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_failed",
+			"message": "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.urlService.UpdateSEORules(userID, uint(urlID), req.Rules); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "url_not_found",
+				"message": err.Error(),
+			})
+			return
+		}
+		if strings.Contains(err.Error(), "unknown SEO rule") {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_rule",
+				"message": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "service_error",
+			"message": "Failed to update SEO rules",
+		})
+		return
+	}
+
+	rules, err := h.urlService.GetSEORules(userID, uint(urlID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "service_error",
+			"message": "SEO rules updated but failed to retrieve current settings",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rules": rules})
+}
+
+// GetCrawlSettings returns the crawl settings for a URL
+func (h *URLHandler) GetCrawlSettings(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get URL ID from params
+	urlID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	settings, err := h.urlService.GetCrawlSettings(userID, uint(urlID))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "url_not_found",
+				"message": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "service_error",
+			"message": "Failed to retrieve crawl settings",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"settings": settings})
+}
+
+// UpdateCrawlSettings changes the crawl settings for a URL
+func (h *URLHandler) UpdateCrawlSettings(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	// Get URL ID from params
+	urlID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": "Invalid URL ID",
+		})
+		return
+	}
+
+	var req UpdateCrawlSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_failed",
+			"message": "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	settings, err := h.urlService.UpdateCrawlSettings(userID, uint(urlID), services.CrawlSettingsUpdate{
+		DetectSoft404:  req.DetectSoft404,
+		CountedClasses: req.CountedClasses,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "url_not_found",
+				"message": err.Error(),
+			})
+			return
+		}
+		if strings.Contains(err.Error(), "invalid link class") {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_class",
+				"message": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "service_error",
+			"message": "Failed to update crawl settings",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"settings": settings})
+}
+
+// GetTechnologies lists the technologies and versions detected across the user's pages
+func (h *URLHandler) GetTechnologies(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	usage, err := h.urlService.GetTechnologyUsage(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "service_error",
+			"message": "Failed to retrieve technologies",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"technologies": usage})
+}
+
+// GetTrackers lists the third-party domains used across the user's pages, optionally filtered
+// by category, with how many pages load them before the consent banner
+func (h *URLHandler) GetTrackers(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "User context not found",
+		})
+		return
+	}
+
+	inventory, err := h.urlService.GetTrackerInventory(userID, c.Query("category"))
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid category") {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_category",
+				"message": "Category must be one of advertising, analytics, social, cdn, consent or other",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "service_error",
+			"message": "Failed to retrieve third-party inventory",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"third_parties": inventory})
+}
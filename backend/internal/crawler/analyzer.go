@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strings"
 	"sync"
 	"time"
 )
@@ -13,16 +12,28 @@ import (
 // LinkAnalyzer provides link analysis functionality
 type LinkAnalyzer struct {
 	client         *http.Client
-	maxConcurrent  int
 	timeout        time.Duration
 	userAgent      string
+	filter         *LinkFilter
+	cache          *LinkCache
+	refresh        bool // Ignore cached outcomes, but still store new ones
+	scheduler      *HostScheduler
+	breaker        *CircuitBreaker
 }
 
-// NewLinkAnalyzer creates a new link analyzer
-func NewLinkAnalyzer(config *CrawlerConfig) *LinkAnalyzer {
+// NewLinkAnalyzer creates a new link analyzer that paces its requests with the given per-host
+// scheduler and circuit breaker, usually shared by all crawls. A nil scheduler or breaker is
+// replaced by one of the analyzer's own.
+func NewLinkAnalyzer(config *CrawlerConfig, scheduler *HostScheduler, breaker *CircuitBreaker) *LinkAnalyzer {
 	if config == nil {
 		config = DefaultConfig()
 	}
+	if scheduler == nil {
+		scheduler = NewHostScheduler(config.HostRequestsPerSec, config.HostBurst, config.MaxInFlight)
+	}
+	if breaker == nil {
+		breaker = NewCircuitBreaker(config.BreakerThreshold, config.BreakerCooldown)
+	}
 
 	return &LinkAnalyzer{
 		client: &http.Client{
@@ -35,9 +46,49 @@ func NewLinkAnalyzer(config *CrawlerConfig) *LinkAnalyzer {
 				return nil
 			},
 		},
-		maxConcurrent: 3, // Much more conservative - only 3 concurrent requests
 		timeout:       10 * time.Second,
 		userAgent:     config.UserAgent,
+		filter:        DefaultLinkFilter(),
+		scheduler:     scheduler,
+		breaker:       breaker,
+	}
+}
+
+// SetFilter replaces the rules deciding which links are checked
+func (la *LinkAnalyzer) SetFilter(filter *LinkFilter) {
+	la.filter = filter
+}
+
+// UseCache makes the analyzer reuse and store outcomes in a shared cache
+func (la *LinkAnalyzer) UseCache(cache *LinkCache, refresh bool) {
+	la.cache = cache
+	la.refresh = refresh
+}
+
+// LinkCheckResult contains the outcome of checking a single link
+type LinkCheckResult struct {
+	URL           string
+	StatusCode    int
+	ContentLength int64  // -1 when the server did not report a size
+	Class         string // Outcome class, e.g. ok, broken, blocked or timeout
+	Error         string // Empty when the link is working
+}
+
+// IsBroken reports whether the link check failed
+func (r *LinkCheckResult) IsBroken() bool {
+	return r.Error != ""
+}
+
+// BrokenInfo converts a failed check into broken link information
+func (r *LinkCheckResult) BrokenInfo() *BrokenLinkInfo {
+	if !r.IsBroken() {
+		return nil
+	}
+	return &BrokenLinkInfo{
+		URL:        r.URL,
+		StatusCode: r.StatusCode,
+		Error:      r.Error,
+		Class:      r.Class,
 	}
 }
 
@@ -47,101 +98,143 @@ func (la *LinkAnalyzer) AnalyzeLinks(ctx context.Context, links []string) []Brok
 		return []BrokenLinkInfo{}
 	}
 
-	// Filter links to avoid checking common external services
-	filteredLinks := la.filterLinksForAnalysis(links)
-	
+	return collectBrokenLinks(links, la.CheckLinks(ctx, links), nil)
+}
+
+// collectBrokenLinks returns the failed links in input order, tagged with their resource type and class
+func collectBrokenLinks(links []string, checks map[string]*LinkCheckResult, resourceTypes map[string]string) []BrokenLinkInfo {
 	var brokenLinks []BrokenLinkInfo
+
+	for _, link := range DeduplicateLinks(links) {
+		check, exists := checks[link]
+		if !exists || !check.IsBroken() {
+			continue
+		}
+
+		brokenInfo := check.BrokenInfo()
+		brokenInfo.ResourceType = ResourceLink
+		if resourceType, exists := resourceTypes[link]; exists {
+			brokenInfo.ResourceType = resourceType
+		}
+		brokenLinks = append(brokenLinks, *brokenInfo)
+	}
+
+	return brokenLinks
+}
+
+// CheckLinks checks a list of links and returns the outcome for every link that was checked
+func (la *LinkAnalyzer) CheckLinks(ctx context.Context, links []string) map[string]*LinkCheckResult {
+	results := make(map[string]*LinkCheckResult)
+	if len(links) == 0 {
+		return results
+	}
+
+	// Filter links according to the include/exclude rules
+	filteredLinks := la.filterLinksForAnalysis(links)
+
+	// Reuse recent outcomes from other analyses
+	if la.cache != nil && !la.refresh {
+		var uncached []string
+		for _, link := range filteredLinks {
+			if cached, exists := la.cache.Get(link); exists {
+				results[link] = cached
+				continue
+			}
+			uncached = append(uncached, link)
+		}
+		filteredLinks = uncached
+	}
+
 	var mutex sync.Mutex
 	var wg sync.WaitGroup
 
-	// Create a semaphore to limit concurrent requests
-	semaphore := make(chan struct{}, la.maxConcurrent)
-
+	// The scheduler paces requests per host, so links on different hosts are checked concurrently
 	for _, link := range filteredLinks {
 		wg.Add(1)
 		go func(url string) {
 			defer wg.Done()
 
-			// Acquire semaphore
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
-
 			// Check if context is cancelled
 			if ctx.Err() != nil {
 				return
 			}
 
-			// Add a small delay to be more respectful to servers
-			time.Sleep(time.Millisecond * 200)
-
 			// Check the link
-			if brokenInfo := la.checkLink(ctx, url); brokenInfo != nil {
-				// Don't report 403 errors as broken links since they're often just bot blocking
-				if brokenInfo.StatusCode != 403 {
-					mutex.Lock()
-					brokenLinks = append(brokenLinks, *brokenInfo)
-					mutex.Unlock()
-				}
-			}
+			check := la.inspectLink(ctx, url)
+			la.storeInCache(ctx, url, check)
+			mutex.Lock()
+			results[url] = check
+			mutex.Unlock()
 		}(link)
 	}
 
 	wg.Wait()
-	return brokenLinks
+	return results
 }
 
-// filterLinksForAnalysis filters out links that are commonly reliable or not worth checking
+// filterLinksForAnalysis filters out links that are excluded by the filter rules
 func (la *LinkAnalyzer) filterLinksForAnalysis(links []string) []string {
-	skipPatterns := []string{
-		// Social media platforms (usually reliable)
-		"facebook.com", "twitter.com", "instagram.com", "linkedin.com", "youtube.com",
-		"tiktok.com", "pinterest.com", "snapchat.com", "whatsapp.com",
-		
-		// Common CDNs and reliable services
-		"googleapis.com", "cloudflare.com", "jsdelivr.net", "unpkg.com",
-		"cdnjs.cloudflare.com", "maxcdn.bootstrapcdn.com",
-		
-		// Major tech companies (usually reliable)
-		"microsoft.com", "apple.com", "amazon.com", "google.com",
-		
-		// Common development tools
-		"github.com", "gitlab.com", "bitbucket.com",
-	}
-
-	var filtered []string
-	for _, link := range links {
-		shouldSkip := false
-		linkLower := strings.ToLower(link)
-		
-		for _, pattern := range skipPatterns {
-			if strings.Contains(linkLower, pattern) {
-				shouldSkip = true
-				break
-			}
-		}
-		
-		if !shouldSkip {
-			filtered = append(filtered, link)
-		}
-	}
-	
+	filtered, _ := la.filter.Apply(links)
 	return filtered
 }
 
+// SkippedLinks returns the links excluded by the filter rules with the reason
+func (la *LinkAnalyzer) SkippedLinks(links []string) []SkippedLink {
+	_, skipped := la.filter.Apply(DeduplicateLinks(links))
+	return skipped
+}
+
 // checkLink checks if a single link is broken
 func (la *LinkAnalyzer) checkLink(ctx context.Context, linkURL string) *BrokenLinkInfo {
+	if la.cache != nil && !la.refresh {
+		if cached, exists := la.cache.Get(linkURL); exists {
+			return cached.BrokenInfo()
+		}
+	}
+
+	check := la.inspectLink(ctx, linkURL)
+	la.storeInCache(ctx, linkURL, check)
+	return check.BrokenInfo()
+}
+
+// storeInCache caches an outcome unless the check was cut short by cancellation. Links
+// skipped by the circuit breaker, throttled or timed out say nothing lasting about the link
+// and are checked again next time.
+func (la *LinkAnalyzer) storeInCache(ctx context.Context, linkURL string, check *LinkCheckResult) {
+	if la.cache == nil || ctx.Err() != nil {
+		return
+	}
+
+	switch check.Class {
+	case LinkClassUnreachable, LinkClassRateLimited, LinkClassTimeout:
+		return
+	}
+	la.cache.Put(linkURL, check)
+}
+
+// inspectLink checks a single link and reports its status and size
+func (la *LinkAnalyzer) inspectLink(ctx context.Context, linkURL string) *LinkCheckResult {
 	// Validate URL first
 	if !IsValidHTTPURL(linkURL) {
-		return &BrokenLinkInfo{
-			URL:        linkURL,
-			StatusCode: 0,
-			Error:      "Invalid URL format",
+		return &LinkCheckResult{
+			URL:           linkURL,
+			StatusCode:    0,
+			ContentLength: -1,
+			Class:         LinkClassBroken,
+			Error:         "Invalid URL format",
 		}
 	}
 
 	// Try HEAD request first (more efficient)
-	if brokenInfo := la.tryRequest(ctx, "HEAD", linkURL); brokenInfo == nil {
-		return nil // Link is working
+	check := la.tryRequest(ctx, "HEAD", linkURL)
+	if !check.IsBroken() {
+		return check // Link is working
+	}
+
+	// Asking again with GET won't help when the host is unreachable or throttling us
+	switch check.Class {
+	case LinkClassRateLimited, LinkClassTimeout, LinkClassDNSFailure:
+		return check
 	}
 
 	// If HEAD fails, try GET request (some servers don't support HEAD)
@@ -149,28 +242,30 @@ func (la *LinkAnalyzer) checkLink(ctx context.Context, linkURL string) *BrokenLi
 }
 
 // tryRequest attempts a single HTTP request with retries
-func (la *LinkAnalyzer) tryRequest(ctx context.Context, method, linkURL string) *BrokenLinkInfo {
+func (la *LinkAnalyzer) tryRequest(ctx context.Context, method, linkURL string) *LinkCheckResult {
 	var lastErr error
 	maxRetries := 2
 
+	failed := func(statusCode int, class, message string) *LinkCheckResult {
+		return &LinkCheckResult{
+			URL:           linkURL,
+			StatusCode:    statusCode,
+			ContentLength: -1,
+			Class:         class,
+			Error:         message,
+		}
+	}
+
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		// Check context first
 		if ctx.Err() != nil {
-			return &BrokenLinkInfo{
-				URL:        linkURL,
-				StatusCode: 0,
-				Error:      "Request cancelled",
-			}
+			return failed(0, LinkClassBroken, "Request cancelled")
 		}
 
 		// Create request with context
 		req, err := http.NewRequestWithContext(ctx, method, linkURL, nil)
 		if err != nil {
-			return &BrokenLinkInfo{
-				URL:        linkURL,
-				StatusCode: 0,
-				Error:      fmt.Sprintf("Failed to create request: %v", err),
-			}
+			return failed(0, LinkClassBroken, fmt.Sprintf("Failed to create request: %v", err))
 		}
 
 		// Set headers
@@ -180,20 +275,39 @@ func (la *LinkAnalyzer) tryRequest(ctx context.Context, method, linkURL string)
 		req.Header.Set("Connection", "keep-alive")
 		req.Header.Set("Cache-Control", "max-age=0")
 
+		// Don't wait on hosts that keep failing
+		if !la.breaker.Allow(linkURL) {
+			return failed(0, LinkClassUnreachable, "Host unreachable (circuit breaker open after repeated failures)")
+		}
+
+		// Wait for the host's turn
+		release, err := la.scheduler.Acquire(ctx, linkURL)
+		if err != nil {
+			return failed(0, LinkClassBroken, "Request cancelled")
+		}
+
 		// Perform request
+		started := time.Now()
 		resp, err := la.client.Do(req)
+		elapsed := time.Since(started)
+		release()
+
 		if err != nil {
 			lastErr = err
+			la.scheduler.Report(linkURL, 0, elapsed, 0)
+			if ctx.Err() == nil {
+				la.breaker.RecordFailure(linkURL)
+			}
+			// DNS failures won't resolve by retrying
+			if classifyError(err) == LinkClassDNSFailure {
+				return failed(0, LinkClassDNSFailure, fmt.Sprintf("DNS lookup failed: %v", err))
+			}
 			// Only retry on network errors, not on HTTP errors
 			if attempt < maxRetries {
 				// Longer delay before retry
 				select {
 				case <-ctx.Done():
-					return &BrokenLinkInfo{
-						URL:        linkURL,
-						StatusCode: 0,
-						Error:      "Request cancelled",
-					}
+					return failed(0, LinkClassBroken, "Request cancelled")
 				case <-time.After(time.Second * 1):
 					continue
 				}
@@ -201,50 +315,51 @@ func (la *LinkAnalyzer) tryRequest(ctx context.Context, method, linkURL string)
 			continue
 		}
 
-		defer resp.Body.Close()
-
 		// Check status code
+		retryAfter, hasRetryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		la.scheduler.Report(linkURL, resp.StatusCode, elapsed, retryAfter)
+		la.breaker.RecordSuccess(linkURL)
+
 		if resp.StatusCode >= 400 {
+			class := classifyResponse(resp)
+			// Close before retrying rather than when the function returns
+			resp.Body.Close()
+
+			// Honor Retry-After when the wait is reasonable; the scheduler pauses the host until then
+			if class == LinkClassRateLimited && attempt < maxRetries && (!hasRetryAfter || retryAfter <= maxRetryAfter) {
+				continue
+			}
+
 			// Don't retry 4xx client errors - they're usually intentional (like 403 bot blocking)
 			if resp.StatusCode >= 400 && resp.StatusCode < 500 {
-				return &BrokenLinkInfo{
-					URL:        linkURL,
-					StatusCode: resp.StatusCode,
-					Error:      fmt.Sprintf("HTTP %d", resp.StatusCode),
-				}
+				return failed(resp.StatusCode, class, describeFailure(resp.StatusCode, class))
 			}
-			
+
 			// Retry 5xx server errors
-			if resp.StatusCode >= 500 && attempt < maxRetries {
+			if resp.StatusCode >= 500 && attempt < maxRetries && class == LinkClassBroken {
 				select {
 				case <-ctx.Done():
-					return &BrokenLinkInfo{
-						URL:        linkURL,
-						StatusCode: 0,
-						Error:      "Request cancelled",
-					}
+					return failed(0, LinkClassBroken, "Request cancelled")
 				case <-time.After(time.Second * 2):
 					continue
 				}
 			}
-			
-			return &BrokenLinkInfo{
-				URL:        linkURL,
-				StatusCode: resp.StatusCode,
-				Error:      fmt.Sprintf("HTTP %d", resp.StatusCode),
-			}
+
+			return failed(resp.StatusCode, class, describeFailure(resp.StatusCode, class))
 		}
 
 		// Success - link is working
-		return nil
+		resp.Body.Close()
+		return &LinkCheckResult{
+			URL:           linkURL,
+			StatusCode:    resp.StatusCode,
+			ContentLength: resp.ContentLength,
+			Class:         LinkClassOK,
+		}
 	}
 
 	// If we get here, all retries failed
-	return &BrokenLinkInfo{
-		URL:        linkURL,
-		StatusCode: 0,
-		Error:      fmt.Sprintf("Failed after %d attempts: %v", maxRetries+1, lastErr),
-	}
+	return failed(0, classifyError(lastErr), fmt.Sprintf("Failed after %d attempts: %v", maxRetries+1, lastErr))
 }
 
 // ClassifyLinks separates internal and external links based on base URL
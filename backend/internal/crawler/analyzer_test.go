@@ -8,7 +8,7 @@ import (
 
 func TestLinkAnalyzer_AnalyzeLinks(t *testing.T) {
 	config := DefaultConfig()
-	analyzer := NewLinkAnalyzer(config)
+	analyzer := NewLinkAnalyzer(config, nil, nil)
 
 	tests := []struct {
 		name  string
@@ -117,7 +117,7 @@ func TestDeduplicateLinks(t *testing.T) {
 }
 
 func TestFilterLinksForAnalysis(t *testing.T) {
-	analyzer := NewLinkAnalyzer(nil)
+	analyzer := NewLinkAnalyzer(nil, nil, nil)
 	
 	links := []string{
 		"https://example.com/page1",           // should be kept
@@ -0,0 +1,197 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Severities of analyzer findings
+const (
+	FindingInfo    = "info"
+	FindingWarning = "warning"
+	FindingError   = "error"
+)
+
+// Limits on what is kept of an analyzer report
+const (
+	maxAnalyzerFindings      = 100
+	maxAnalyzerMetricName    = 100
+	maxAnalyzerMessageLength = 500
+)
+
+// PageContext is what a page analyzer can inspect. The response body has already been read.
+type PageContext struct {
+	Context  context.Context
+	URL      *url.URL
+	Response *http.Response // Nil when the page wasn't fetched over HTTP, e.g. in tests
+	Document *goquery.Document
+	Parsed   *ParseResult
+}
+
+// AnalyzerFinding is a problem reported by a page analyzer
+type AnalyzerFinding struct {
+	Severity string // FindingInfo, FindingWarning or FindingError
+	Message  string
+}
+
+// AnalyzerReport is the output of a page analyzer: named metrics and findings
+type AnalyzerReport struct {
+	Metrics  map[string]float64
+	Findings []AnalyzerFinding
+}
+
+// AnalyzerResult is the report of one analyzer for a page
+type AnalyzerResult struct {
+	AnalyzerID string
+	Metrics    map[string]float64
+	Findings   []AnalyzerFinding
+	Error      string // Set when the analyzer failed
+}
+
+// PageAnalyzer is a check run against every analyzed page. Analyzers are registered with
+// RegisterAnalyzer, usually from an init function, and can be disabled per user.
+type PageAnalyzer interface {
+	ID() string // Stable identifier used for settings and stored results, e.g. "security-headers"
+	Name() string
+	Description() string
+	Analyze(page *PageContext) (AnalyzerReport, error)
+}
+
+// funcAnalyzer adapts a function to the PageAnalyzer interface
+type funcAnalyzer struct {
+	id          string
+	name        string
+	description string
+	analyze     func(page *PageContext) (AnalyzerReport, error)
+}
+
+func (a *funcAnalyzer) ID() string          { return a.id }
+func (a *funcAnalyzer) Name() string        { return a.name }
+func (a *funcAnalyzer) Description() string { return a.description }
+
+func (a *funcAnalyzer) Analyze(page *PageContext) (AnalyzerReport, error) {
+	return a.analyze(page)
+}
+
+// NewAnalyzer creates a page analyzer from a function
+func NewAnalyzer(id, name, description string, analyze func(page *PageContext) (AnalyzerReport, error)) PageAnalyzer {
+	return &funcAnalyzer{id: id, name: name, description: description, analyze: analyze}
+}
+
+var (
+	analyzersMu sync.RWMutex
+	analyzers   = make(map[string]PageAnalyzer)
+)
+
+// RegisterAnalyzer makes a page analyzer available to every crawl. It panics when the ID
+// is empty or already registered.
+func RegisterAnalyzer(analyzer PageAnalyzer) {
+	analyzersMu.Lock()
+	defer analyzersMu.Unlock()
+
+	id := analyzer.ID()
+	if id == "" {
+		panic("crawler: page analyzer without an ID")
+	}
+	if _, exists := analyzers[id]; exists {
+		panic("crawler: page analyzer registered twice: " + id)
+	}
+	analyzers[id] = analyzer
+}
+
+// Analyzers returns the registered page analyzers sorted by ID
+func Analyzers() []PageAnalyzer {
+	analyzersMu.RLock()
+	defer analyzersMu.RUnlock()
+
+	list := make([]PageAnalyzer, 0, len(analyzers))
+	for _, analyzer := range analyzers {
+		list = append(list, analyzer)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID() < list[j].ID()
+	})
+	return list
+}
+
+// FindAnalyzer looks up a registered page analyzer by ID
+func FindAnalyzer(id string) (PageAnalyzer, bool) {
+	analyzersMu.RLock()
+	defer analyzersMu.RUnlock()
+
+	analyzer, exists := analyzers[id]
+	return analyzer, exists
+}
+
+// RunAnalyzers runs the registered analyzers that aren't disabled against a page
+func RunAnalyzers(page *PageContext, disabled map[string]bool) []AnalyzerResult {
+	results := []AnalyzerResult{}
+	for _, analyzer := range Analyzers() {
+		if disabled[analyzer.ID()] {
+			continue
+		}
+		if page.Context != nil && page.Context.Err() != nil {
+			break
+		}
+		results = append(results, runAnalyzer(analyzer, page))
+	}
+	return results
+}
+
+// runAnalyzer runs one analyzer, turning errors and panics into a failed result so that
+// a faulty check can't abort the crawl
+func runAnalyzer(analyzer PageAnalyzer, page *PageContext) (result AnalyzerResult) {
+	result.AnalyzerID = analyzer.ID()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[CRAWLER] Page analyzer %s panicked: %v", analyzer.ID(), r)
+			result = AnalyzerResult{AnalyzerID: analyzer.ID(), Error: truncateRunes(fmt.Sprintf("analyzer panicked: %v", r), maxAnalyzerMessageLength)}
+		}
+	}()
+
+	report, err := analyzer.Analyze(page)
+	if err != nil {
+		result.Error = truncateRunes(err.Error(), maxAnalyzerMessageLength)
+		return result
+	}
+
+	// Keep what can be stored: finite values, bounded names and messages, known severities
+	result.Metrics = make(map[string]float64, len(report.Metrics))
+	for name, value := range report.Metrics {
+		if name == "" || math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		result.Metrics[truncateRunes(name, maxAnalyzerMetricName)] = value
+	}
+	for _, finding := range report.Findings {
+		if len(result.Findings) >= maxAnalyzerFindings {
+			break
+		}
+		if finding.Severity != FindingWarning && finding.Severity != FindingError {
+			finding.Severity = FindingInfo
+		}
+		finding.Message = truncateRunes(finding.Message, maxAnalyzerMessageLength)
+		result.Findings = append(result.Findings, finding)
+	}
+	return result
+}
+
+// maxStoredURLLength is the size of the URL columns; longer URLs cannot be stored
+const maxStoredURLLength = 2048
+
+// truncateRunes shortens a string to at most limit characters
+func truncateRunes(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	return string([]rune(s)[:limit])
+}
@@ -0,0 +1,102 @@
+package crawler
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IDs of the built-in page analyzers
+const (
+	AnalyzerSecurityHeaders = "security-headers"
+	AnalyzerPageWeight      = "page-weight"
+)
+
+// maxDOMElements is the DOM size above which rendering slows down noticeably
+const maxDOMElements = 1500
+
+func init() {
+	RegisterAnalyzer(NewAnalyzer(AnalyzerSecurityHeaders, "Security headers",
+		"Checks the response for HSTS, Content-Security-Policy, clickjacking, MIME sniffing and referrer headers",
+		analyzeSecurityHeaders))
+	RegisterAnalyzer(NewAnalyzer(AnalyzerPageWeight, "Page weight",
+		fmt.Sprintf("Measures HTML size, scripts, stylesheets and DOM size; warns above %d DOM elements", maxDOMElements),
+		analyzePageWeight))
+}
+
+// analyzeSecurityHeaders reports missing security response headers
+func analyzeSecurityHeaders(page *PageContext) (AnalyzerReport, error) {
+	report := AnalyzerReport{Metrics: map[string]float64{}}
+	if page.Response == nil {
+		return report, nil
+	}
+	headers := page.Response.Header
+	csp := strings.ToLower(headers.Get("Content-Security-Policy"))
+
+	checks := []struct {
+		present  bool
+		severity string
+		message  string
+	}{
+		{
+			present:  page.URL.Scheme != "https" || headers.Get("Strict-Transport-Security") != "",
+			severity: FindingWarning,
+			message:  "Strict-Transport-Security header is missing",
+		},
+		{
+			present:  csp != "",
+			severity: FindingWarning,
+			message:  "Content-Security-Policy header is missing",
+		},
+		{
+			present:  headers.Get("X-Frame-Options") != "" || strings.Contains(csp, "frame-ancestors"),
+			severity: FindingWarning,
+			message:  "Neither X-Frame-Options nor a CSP frame-ancestors directive protects against clickjacking",
+		},
+		{
+			present:  strings.EqualFold(strings.TrimSpace(headers.Get("X-Content-Type-Options")), "nosniff"),
+			severity: FindingWarning,
+			message:  "X-Content-Type-Options: nosniff header is missing",
+		},
+		{
+			present:  headers.Get("Referrer-Policy") != "",
+			severity: FindingInfo,
+			message:  "Referrer-Policy header is missing",
+		},
+	}
+
+	present := 0
+	for _, check := range checks {
+		if check.present {
+			present++
+			continue
+		}
+		report.Findings = append(report.Findings, AnalyzerFinding{Severity: check.severity, Message: check.message})
+	}
+	report.Metrics["headers_present"] = float64(present)
+	report.Metrics["headers_checked"] = float64(len(checks))
+
+	return report, nil
+}
+
+// analyzePageWeight measures how heavy the page markup and its references are
+func analyzePageWeight(page *PageContext) (AnalyzerReport, error) {
+	report := AnalyzerReport{Metrics: map[string]float64{}}
+	doc := page.Document
+
+	domElements := doc.Find("*").Length()
+	report.Metrics["html_bytes"] = float64(page.Parsed.HTMLSize)
+	report.Metrics["dom_elements"] = float64(domElements)
+	report.Metrics["external_scripts"] = float64(doc.Find("script[src]").Length())
+	report.Metrics["inline_scripts"] = float64(doc.Find("script:not([src])").Length())
+	report.Metrics["stylesheets"] = float64(doc.Find("link[rel~=stylesheet]").Length())
+	report.Metrics["inline_styles"] = float64(doc.Find("style").Length())
+
+	if domElements > maxDOMElements {
+		report.Findings = append(report.Findings, AnalyzerFinding{
+			Severity: FindingWarning,
+			Message:  fmt.Sprintf("Page has %d DOM elements (recommended at most %d)", domElements, maxDOMElements),
+		})
+	}
+
+	return report, nil
+}
@@ -0,0 +1,108 @@
+package crawler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// registerTestAnalyzer registers an analyzer for the duration of a test
+func registerTestAnalyzer(t *testing.T, analyzer PageAnalyzer) {
+	t.Helper()
+	RegisterAnalyzer(analyzer)
+	t.Cleanup(func() {
+		analyzersMu.Lock()
+		delete(analyzers, analyzer.ID())
+		analyzersMu.Unlock()
+	})
+}
+
+func TestRunAnalyzers(t *testing.T) {
+	registerTestAnalyzer(t, NewAnalyzer("test-ok", "OK", "Reports a metric", func(page *PageContext) (AnalyzerReport, error) {
+		return AnalyzerReport{
+			Metrics: map[string]float64{"links": float64(len(page.Parsed.InternalLinks)), "ratio": math.NaN()},
+			Findings: []AnalyzerFinding{
+				{Severity: "critical", Message: strings.Repeat("x", 600)},
+			},
+		}, nil
+	}))
+	registerTestAnalyzer(t, NewAnalyzer("test-error", "Error", "Fails", func(page *PageContext) (AnalyzerReport, error) {
+		return AnalyzerReport{}, errors.New("upstream unavailable")
+	}))
+	registerTestAnalyzer(t, NewAnalyzer("test-panic", "Panic", "Panics", func(page *PageContext) (AnalyzerReport, error) {
+		var parsed *ParseResult
+		return AnalyzerReport{Metrics: map[string]float64{"title": float64(len(parsed.Title))}}, nil
+	}))
+
+	parseResult, err := ParseHTML(strings.NewReader(`<html><body><a href="/a">A</a></body></html>`), "https://example.com/")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	page := &PageContext{URL: &url.URL{Scheme: "https", Host: "example.com"}, Document: parseResult.Document, Parsed: parseResult}
+
+	results := make(map[string]AnalyzerResult)
+	for _, result := range RunAnalyzers(page, map[string]bool{AnalyzerPageWeight: true}) {
+		results[result.AnalyzerID] = result
+	}
+
+	if _, ran := results[AnalyzerPageWeight]; ran {
+		t.Error("Expected the disabled analyzer not to run")
+	}
+	if _, ran := results[AnalyzerSecurityHeaders]; !ran {
+		t.Error("Expected the built-in security headers analyzer to run")
+	}
+
+	ok := results["test-ok"]
+	if ok.Metrics["links"] != 1 || len(ok.Metrics) != 1 {
+		t.Errorf("Expected only the finite metric to be kept, got %v", ok.Metrics)
+	}
+	if len(ok.Findings) != 1 || ok.Findings[0].Severity != FindingInfo || len(ok.Findings[0].Message) != maxAnalyzerMessageLength {
+		t.Errorf("Expected an unknown severity to become info and the message to be truncated, got %+v", ok.Findings)
+	}
+
+	if results["test-error"].Error != "upstream unavailable" {
+		t.Errorf("Expected the analyzer error to be recorded, got %q", results["test-error"].Error)
+	}
+	if !strings.HasPrefix(results["test-panic"].Error, "analyzer panicked") {
+		t.Errorf("Expected the panic to be recorded, got %q", results["test-panic"].Error)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected registering a duplicate analyzer ID to panic")
+			}
+		}()
+		RegisterAnalyzer(NewAnalyzer("test-ok", "Duplicate", "", nil))
+	}()
+}
+
+func TestSecurityHeadersAnalyzer(t *testing.T) {
+	analyzer, exists := FindAnalyzer(AnalyzerSecurityHeaders)
+	if !exists {
+		t.Fatal("Expected the security headers analyzer to be registered")
+	}
+
+	headers := http.Header{}
+	headers.Set("Content-Security-Policy", "default-src 'self'; frame-ancestors 'none'")
+	headers.Set("X-Content-Type-Options", "nosniff")
+	page := &PageContext{
+		URL:      &url.URL{Scheme: "https", Host: "example.com"},
+		Response: &http.Response{Header: headers},
+	}
+
+	report, err := analyzer.Analyze(page)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.Metrics["headers_present"] != 3 || report.Metrics["headers_checked"] != 5 {
+		t.Errorf("Unexpected metrics: %v", report.Metrics)
+	}
+	if len(report.Findings) != 2 || !strings.Contains(report.Findings[0].Message, "Strict-Transport-Security") ||
+		report.Findings[1].Severity != FindingInfo {
+		t.Errorf("Expected missing HSTS and Referrer-Policy findings, got %+v", report.Findings)
+	}
+}
@@ -0,0 +1,210 @@
+package crawler
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/andybalholm/cascadia"
+)
+
+// Assertion types
+const (
+	AssertStatusCode      = "status_code"       // Value is the expected HTTP status code
+	AssertContainsText    = "contains_text"     // Value must appear in the visible text
+	AssertNotContainsText = "not_contains_text" // Value must not appear in the visible text
+	AssertSelectorExists  = "selector_exists"   // Value is a CSS selector that must match
+	AssertMaxResponseTime = "max_response_time" // Value is the slowest acceptable response, in milliseconds
+	AssertMinLinks        = "min_links"         // Value is the fewest internal and external links expected
+	AssertTitleMatches    = "title_matches"     // Value is a regular expression the title must match
+)
+
+// maxAssertionActualLength bounds the actual values kept, such as the page title
+const maxAssertionActualLength = 200
+
+// Assertion is a check run against a page after it is crawled, for synthetic monitoring
+type Assertion struct {
+	ID    uint
+	Type  string
+	Value string
+}
+
+// AssertionResult is the outcome of an assertion for one crawl
+type AssertionResult struct {
+	AssertionID uint
+	Type        string
+	Expected    string
+	Actual      string
+	Passed      bool
+	Message     string // Explains a failure
+}
+
+// ValidateAssertion checks the assertion type and that its value can be evaluated
+func ValidateAssertion(assertion Assertion) error {
+	value := strings.TrimSpace(assertion.Value)
+	switch assertion.Type {
+	case AssertStatusCode:
+		code, err := strconv.Atoi(value)
+		if err != nil || code < 100 || code > 599 {
+			return fmt.Errorf("invalid status code: %q", assertion.Value)
+		}
+	case AssertContainsText, AssertNotContainsText:
+		if value == "" {
+			return fmt.Errorf("invalid assertion: text is required")
+		}
+	case AssertSelectorExists:
+		if _, err := cascadia.Compile(value); err != nil {
+			return fmt.Errorf("invalid CSS selector %q: %w", assertion.Value, err)
+		}
+	case AssertMaxResponseTime, AssertMinLinks:
+		if number, err := strconv.Atoi(value); err != nil || number < 0 {
+			return fmt.Errorf("invalid number for %s: %q", assertion.Type, assertion.Value)
+		}
+	case AssertTitleMatches:
+		if _, err := regexp.Compile(value); err != nil {
+			return fmt.Errorf("invalid regular expression %q: %w", assertion.Value, err)
+		}
+	default:
+		return fmt.Errorf("invalid assertion type: %q", assertion.Type)
+	}
+	return nil
+}
+
+// EvaluateAssertions checks a crawl result against the assertions. Assertions about the page
+// content fail when the page couldn't be fetched or parsed.
+func EvaluateAssertions(result *CrawlResult, assertions []Assertion) []AssertionResult {
+	results := []AssertionResult{}
+	if len(assertions) == 0 {
+		return results
+	}
+
+	var pageText string
+	if result.ParseResult != nil && result.ParseResult.Document != nil {
+		body := result.ParseResult.Document.Find("body").Clone()
+		body.Find("script, style, noscript, template").Remove()
+		pageText = SanitizeText(strings.Join(textBlocks(body), " "))
+	}
+
+	for _, assertion := range assertions {
+		outcome := AssertionResult{
+			AssertionID: assertion.ID,
+			Type:        assertion.Type,
+			Expected:    assertion.Value,
+		}
+		if err := ValidateAssertion(assertion); err != nil {
+			outcome.Message = err.Error()
+			results = append(results, outcome)
+			continue
+		}
+		value := strings.TrimSpace(assertion.Value)
+
+		switch assertion.Type {
+		case AssertStatusCode:
+			expected, _ := strconv.Atoi(value)
+			if result.StatusCode == 0 {
+				outcome.Message = "No response: " + result.Error
+				break
+			}
+			outcome.Actual = strconv.Itoa(result.StatusCode)
+			outcome.Passed = result.StatusCode == expected
+			if !outcome.Passed {
+				outcome.Message = fmt.Sprintf("Expected status %d, got %d", expected, result.StatusCode)
+			}
+
+		case AssertMaxResponseTime:
+			limit, _ := strconv.Atoi(value)
+			if result.StatusCode == 0 {
+				outcome.Message = "No response: " + result.Error
+				break
+			}
+			elapsed := result.ResponseTime.Milliseconds()
+			outcome.Actual = strconv.FormatInt(elapsed, 10)
+			outcome.Passed = result.ResponseTime <= time.Duration(limit)*time.Millisecond
+			if !outcome.Passed {
+				outcome.Message = fmt.Sprintf("Response took %d ms, limit is %d ms", elapsed, limit)
+			}
+
+		default:
+			// The remaining assertions need the parsed page
+			if result.ParseResult == nil {
+				outcome.Message = "Page content unavailable: " + result.Error
+				break
+			}
+			evaluatePageAssertion(&outcome, assertion.Type, value, result, pageText)
+		}
+
+		outcome.Actual = truncateRunes(outcome.Actual, maxAssertionActualLength)
+		outcome.Message = truncateRunes(outcome.Message, maxAnalyzerMessageLength)
+		results = append(results, outcome)
+	}
+
+	return results
+}
+
+// evaluatePageAssertion checks an assertion about the parsed page content
+func evaluatePageAssertion(outcome *AssertionResult, assertionType, value string, result *CrawlResult, pageText string) {
+	switch assertionType {
+	case AssertContainsText:
+		outcome.Passed = strings.Contains(pageText, value)
+		if !outcome.Passed {
+			outcome.Message = fmt.Sprintf("Page doesn't contain %q", value)
+		}
+
+	case AssertNotContainsText:
+		if index := strings.Index(pageText, value); index >= 0 {
+			outcome.Actual = textAround(pageText, index, len(value))
+			outcome.Message = fmt.Sprintf("Page contains forbidden text %q", value)
+		} else {
+			outcome.Passed = true
+		}
+
+	case AssertSelectorExists:
+		selector, _ := cascadia.Compile(value)
+		matches := result.ParseResult.Document.FindMatcher(selector).Length()
+		outcome.Actual = strconv.Itoa(matches)
+		outcome.Passed = matches > 0
+		if !outcome.Passed {
+			outcome.Message = fmt.Sprintf("No element matches %q", value)
+		}
+
+	case AssertMinLinks:
+		minimum, _ := strconv.Atoi(value)
+		links := result.InternalLinks + result.ExternalLinks
+		outcome.Actual = strconv.Itoa(links)
+		outcome.Passed = links >= minimum
+		if !outcome.Passed {
+			outcome.Message = fmt.Sprintf("Page has %d links, expected at least %d", links, minimum)
+		}
+
+	case AssertTitleMatches:
+		title := regexp.MustCompile(value)
+		outcome.Actual = result.Title
+		outcome.Passed = title.MatchString(result.Title)
+		if !outcome.Passed {
+			outcome.Message = fmt.Sprintf("Title doesn't match %q", value)
+		}
+	}
+}
+
+// textAround returns the match at index with some surrounding text for context
+func textAround(text string, index, length int) string {
+	const context = 40
+	start, end := index-context, index+length+context
+	if start < 0 {
+		start = 0
+	}
+	if end > len(text) {
+		end = len(text)
+	}
+	// Don't cut multi-byte characters in half
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+	return strings.TrimSpace(text[start:end])
+}
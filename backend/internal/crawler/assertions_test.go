@@ -0,0 +1,108 @@
+package crawler
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvaluateAssertions(t *testing.T) {
+	html := `<html><head><title>Pricing | Example</title></head><body>
+		<h1>Simple pricing</h1>
+		<div id="plans"><a href="/signup">Start free trial</a><a href="https://docs.example.org/">Docs</a></div>
+		<p>Plans from undefined per month</p>
+		<script>var shown = "Fatal error";</script>
+	</body></html>`
+
+	parseResult, err := ParseHTML(strings.NewReader(html), "https://example.com/pricing")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	result := &CrawlResult{
+		StatusCode:    200,
+		ResponseTime:  350 * time.Millisecond,
+		Title:         parseResult.Title,
+		InternalLinks: len(parseResult.InternalLinks),
+		ExternalLinks: len(parseResult.ExternalLinks),
+		ParseResult:   parseResult,
+	}
+
+	assertions := []Assertion{
+		{ID: 1, Type: AssertStatusCode, Value: "200"},
+		{ID: 2, Type: AssertContainsText, Value: "Start free trial"},
+		{ID: 3, Type: AssertNotContainsText, Value: "undefined"},
+		{ID: 4, Type: AssertNotContainsText, Value: "Fatal error"},
+		{ID: 5, Type: AssertSelectorExists, Value: "#plans a[href='/signup']"},
+		{ID: 6, Type: AssertSelectorExists, Value: ".testimonials"},
+		{ID: 7, Type: AssertMaxResponseTime, Value: "300"},
+		{ID: 8, Type: AssertMinLinks, Value: "2"},
+		{ID: 9, Type: AssertTitleMatches, Value: `^Pricing \|`},
+		{ID: 10, Type: AssertStatusCode, Value: "301"},
+	}
+	expected := map[uint]bool{1: true, 2: true, 3: false, 4: true, 5: true, 6: false, 7: false, 8: true, 9: true, 10: false}
+
+	results := EvaluateAssertions(result, assertions)
+	if len(results) != len(assertions) {
+		t.Fatalf("Expected %d results, got %d", len(assertions), len(results))
+	}
+	for _, outcome := range results {
+		if outcome.Passed != expected[outcome.AssertionID] {
+			t.Errorf("Assertion %d (%s): expected passed=%v, got %+v", outcome.AssertionID, outcome.Type, expected[outcome.AssertionID], outcome)
+		}
+		if !outcome.Passed && outcome.Message == "" {
+			t.Errorf("Assertion %d failed without a message", outcome.AssertionID)
+		}
+	}
+	if !strings.Contains(results[2].Actual, "from undefined per") {
+		t.Errorf("Expected the forbidden text in context, got %q", results[2].Actual)
+	}
+	if results[6].Actual != "350" {
+		t.Errorf("Expected the response time in milliseconds, got %q", results[6].Actual)
+	}
+}
+
+func TestEvaluateAssertionsFailedCrawl(t *testing.T) {
+	result := &CrawlResult{Error: "Failed to fetch URL: no response after 4 attempts: connection refused"}
+	assertions := []Assertion{
+		{ID: 1, Type: AssertStatusCode, Value: "200"},
+		{ID: 2, Type: AssertNotContainsText, Value: "Fatal error"},
+	}
+
+	for _, outcome := range EvaluateAssertions(result, assertions) {
+		if outcome.Passed {
+			t.Errorf("Expected assertion %d to fail when the page couldn't be fetched", outcome.AssertionID)
+		}
+		if !strings.Contains(outcome.Message, "connection refused") {
+			t.Errorf("Expected the crawl error in the message, got %q", outcome.Message)
+		}
+	}
+
+	if results := EvaluateAssertions(result, nil); len(results) != 0 {
+		t.Errorf("Expected no results without assertions, got %d", len(results))
+	}
+}
+
+func TestValidateAssertion(t *testing.T) {
+	tests := []struct {
+		assertion Assertion
+		valid     bool
+	}{
+		{Assertion{Type: AssertStatusCode, Value: "404"}, true},
+		{Assertion{Type: AssertStatusCode, Value: "2xx"}, false},
+		{Assertion{Type: AssertStatusCode, Value: "700"}, false},
+		{Assertion{Type: AssertContainsText, Value: "  "}, false},
+		{Assertion{Type: AssertSelectorExists, Value: "main > .hero"}, true},
+		{Assertion{Type: AssertSelectorExists, Value: "div[["}, false},
+		{Assertion{Type: AssertMaxResponseTime, Value: "-5"}, false},
+		{Assertion{Type: AssertMinLinks, Value: "10"}, true},
+		{Assertion{Type: AssertTitleMatches, Value: "(unclosed"}, false},
+		{Assertion{Type: "word_count", Value: "100"}, false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateAssertion(tt.assertion)
+		if (err == nil) != tt.valid {
+			t.Errorf("%s %q: expected valid=%v, got error %v", tt.assertion.Type, tt.assertion.Value, tt.valid, err)
+		}
+	}
+}
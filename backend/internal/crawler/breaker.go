@@ -0,0 +1,161 @@
+package crawler
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Circuit breaker states
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+// CircuitBreaker stops requests to hosts that keep failing at the network level.
+// After threshold consecutive failures the host's breaker opens and requests are
+// short-circuited; after the cooldown a single trial request is let through
+// (half-open) and its outcome closes or re-opens the breaker. Hosts that nobody has
+// requested for longer than the cooldown are forgotten.
+type CircuitBreaker struct {
+	mutex     sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	hosts     map[string]*hostBreaker
+	now       func() time.Time
+}
+
+// hostBreaker is the breaker state of a single host
+type hostBreaker struct {
+	state               string
+	consecutiveFailures int
+	openedAt            time.Time
+	trialStartedAt      time.Time // Zero when no trial request is in flight
+	lastSeen            time.Time // Last request or failure
+}
+
+// BreakerState describes the breaker of a host
+type BreakerState struct {
+	Host                string    `json:"host"`
+	State               string    `json:"state"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	OpenedAt            time.Time `json:"opened_at,omitempty"`
+	RetryAt             time.Time `json:"retry_at,omitempty"`
+}
+
+// NewCircuitBreaker creates a circuit breaker
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		hosts:     make(map[string]*hostBreaker),
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether a request to the URL's host may be sent
+func (cb *CircuitBreaker) Allow(rawURL string) bool {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	breaker, exists := cb.hosts[schedulerHost(rawURL)]
+	if !exists {
+		return true
+	}
+
+	now := cb.now()
+	breaker.lastSeen = now
+	switch breaker.state {
+	case BreakerOpen:
+		if now.Sub(breaker.openedAt) < cb.cooldown {
+			return false
+		}
+		breaker.state = BreakerHalfOpen
+		breaker.trialStartedAt = now
+		return true
+	case BreakerHalfOpen:
+		// Only one trial request at a time, unless the trial never reported back
+		if !breaker.trialStartedAt.IsZero() && now.Sub(breaker.trialStartedAt) < cb.cooldown {
+			return false
+		}
+		breaker.trialStartedAt = now
+		return true
+	}
+
+	return true
+}
+
+// RecordSuccess records that the host answered, closing its breaker
+func (cb *CircuitBreaker) RecordSuccess(rawURL string) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	// Healthy hosts need no state
+	delete(cb.hosts, schedulerHost(rawURL))
+}
+
+// RecordFailure records a network failure, opening the breaker at the threshold
+func (cb *CircuitBreaker) RecordFailure(rawURL string) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	now := cb.now()
+	host := schedulerHost(rawURL)
+	breaker, exists := cb.hosts[host]
+	if !exists {
+		cb.pruneIdleHosts(now)
+		breaker = &hostBreaker{state: BreakerClosed}
+		cb.hosts[host] = breaker
+	}
+
+	breaker.lastSeen = now
+	breaker.consecutiveFailures++
+	breaker.trialStartedAt = time.Time{}
+
+	if breaker.state == BreakerHalfOpen || breaker.consecutiveFailures >= cb.threshold {
+		breaker.state = BreakerOpen
+		breaker.openedAt = now
+	}
+}
+
+// States returns the breakers of hosts with recent failures, sorted by host
+func (cb *CircuitBreaker) States() []BreakerState {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	cb.pruneIdleHosts(cb.now())
+
+	states := []BreakerState{}
+	for host, breaker := range cb.hosts {
+		state := BreakerState{
+			Host:                host,
+			State:               breaker.state,
+			ConsecutiveFailures: breaker.consecutiveFailures,
+		}
+		if breaker.state != BreakerClosed {
+			state.OpenedAt = breaker.openedAt
+			state.RetryAt = breaker.openedAt.Add(cb.cooldown)
+		}
+		states = append(states, state)
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Host < states[j].Host
+	})
+	return states
+}
+
+// pruneIdleHosts forgets hosts that haven't been requested for longer than the cooldown.
+// Their next request is let through as if the breaker had closed.
+func (cb *CircuitBreaker) pruneIdleHosts(now time.Time) {
+	for host, breaker := range cb.hosts {
+		if now.Sub(breaker.lastSeen) > cb.cooldown {
+			delete(cb.hosts, host)
+		}
+	}
+}
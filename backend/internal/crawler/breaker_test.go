@@ -0,0 +1,146 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerStates(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(3, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	host := "https://cdn.example.com/lib.js"
+	for i := 0; i < 3; i++ {
+		if !breaker.Allow(host) {
+			t.Fatalf("Expected request %d to be allowed before the threshold", i+1)
+		}
+		breaker.RecordFailure(host)
+	}
+
+	if breaker.Allow(host) {
+		t.Error("Expected the breaker to be open after 3 failures")
+	}
+	if !breaker.Allow("https://other.example.com/") {
+		t.Error("Expected other hosts to be unaffected")
+	}
+
+	// After the cooldown a single trial request is allowed
+	now = now.Add(time.Minute)
+	if !breaker.Allow(host) {
+		t.Fatal("Expected a trial request after the cooldown")
+	}
+	if breaker.Allow(host) {
+		t.Error("Expected only one trial request while half-open")
+	}
+	if states := breaker.States(); len(states) != 1 || states[0].State != BreakerHalfOpen {
+		t.Errorf("Expected a half-open breaker, got %+v", states)
+	}
+
+	// A failed trial re-opens the breaker, a successful one closes it
+	breaker.RecordFailure(host)
+	if breaker.Allow(host) {
+		t.Error("Expected the breaker to re-open after a failed trial")
+	}
+
+	now = now.Add(time.Minute)
+	breaker.Allow(host)
+	breaker.RecordSuccess(host)
+	if !breaker.Allow(host) || len(breaker.States()) != 0 {
+		t.Error("Expected the breaker to close after a successful trial")
+	}
+}
+
+func TestCircuitBreakerForgetsIdleHosts(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.RecordFailure("https://dead.example.com/")
+	breaker.RecordFailure("https://busy.example.com/")
+
+	// The busy host keeps being requested, the dead one is never asked for again
+	for i := 0; i < 3; i++ {
+		now = now.Add(45 * time.Second)
+		breaker.Allow("https://busy.example.com/")
+	}
+
+	states := breaker.States()
+	if len(states) != 1 || states[0].Host != "busy.example.com" {
+		t.Errorf("Expected only the busy host to be tracked, got %+v", states)
+	}
+}
+
+func TestCheckLinksShortCircuitsDeadHost(t *testing.T) {
+	// A listener that is closed right away refuses every connection
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	deadURL := server.URL
+	server.Close()
+
+	analyzer := NewLinkAnalyzer(nil, nil, NewCircuitBreaker(2, time.Minute))
+
+	links := []string{deadURL + "/a", deadURL + "/b", deadURL + "/c", deadURL + "/d"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	started := time.Now()
+	checks := analyzer.CheckLinks(ctx, links)
+	if elapsed := time.Since(started); elapsed > 10*time.Second {
+		t.Errorf("Expected the dead host to be short-circuited, took %v", elapsed)
+	}
+
+	unreachable := 0
+	for _, link := range links {
+		check := checks[link]
+		if check == nil || !check.IsBroken() {
+			t.Fatalf("Expected %s to fail", link)
+		}
+		if check.Class == LinkClassUnreachable {
+			unreachable++
+		}
+	}
+	if unreachable == 0 {
+		t.Error("Expected some links to be short-circuited as host unreachable")
+	}
+}
+
+func TestCheckLinksRechecksAfterBreakerCloses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	breaker := NewCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+	cache := NewLinkCache(10, time.Hour, time.Hour)
+	links := []string{server.URL + "/page"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	check := func() *LinkCheckResult {
+		analyzer := NewLinkAnalyzer(nil, nil, breaker)
+		analyzer.UseCache(cache, false)
+		return analyzer.CheckLinks(ctx, links)[links[0]]
+	}
+
+	// An earlier failure opened the host's breaker
+	breaker.RecordFailure(links[0])
+	if result := check(); result == nil || result.Class != LinkClassUnreachable {
+		t.Fatalf("Expected the link to be short-circuited, got %+v", result)
+	}
+
+	// After the cooldown the link is checked again instead of served from the cache
+	now = now.Add(2 * time.Minute)
+	if result := check(); result == nil || result.Class != LinkClassOK {
+		t.Errorf("Expected the link to be checked again after the breaker closed, got %+v", result)
+	}
+	if len(breaker.States()) != 0 {
+		t.Error("Expected the successful check to close the breaker")
+	}
+}
@@ -0,0 +1,274 @@
+package crawler
+
+import (
+	"io"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// boilerplateSelector matches page chrome and non-visible elements left out of the main content
+const boilerplateSelector = "script, style, noscript, template, svg, iframe, nav, header, footer, aside, " +
+	"[role=navigation], [role=banner], [role=contentinfo], [role=complementary], [hidden], [aria-hidden=true]"
+
+// minLanguageMatches is the number of stopword hits needed before a language is reported
+const minLanguageMatches = 5
+
+// blockElements end a run of text, so adjacent blocks aren't glued into one word or sentence
+var blockElements = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "ul": true, "ol": true, "dl": true, "dt": true, "dd": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "section": true, "article": true,
+	"main": true, "blockquote": true, "pre": true, "table": true, "tr": true, "td": true, "th": true,
+	"figure": true, "figcaption": true, "hr": true, "address": true, "details": true, "summary": true,
+}
+
+// languageStopwords are frequent function words used to guess the language of Latin and Cyrillic text
+var languageStopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "in", "that", "it", "for", "with", "are", "this", "you", "on"},
+	"de": {"der", "die", "und", "das", "ist", "nicht", "mit", "den", "ein", "eine", "zu", "auf", "sich", "auch"},
+	"fr": {"le", "la", "les", "et", "est", "des", "une", "dans", "pour", "que", "qui", "sur", "pas", "du"},
+	"es": {"el", "la", "los", "las", "y", "es", "que", "del", "en", "por", "una", "para", "con", "se"},
+	"it": {"il", "di", "che", "è", "della", "per", "una", "sono", "gli", "con", "del", "non", "le", "si"},
+	"nl": {"de", "het", "een", "en", "van", "is", "dat", "niet", "op", "voor", "met", "zijn", "ook", "te"},
+	"pt": {"o", "os", "de", "que", "não", "uma", "para", "com", "do", "da", "em", "se", "mais", "é"},
+	"sv": {"och", "att", "det", "som", "är", "en", "på", "för", "med", "inte", "av", "till", "den", "har"},
+	"ru": {"и", "в", "не", "на", "что", "с", "по", "это", "как", "для", "из", "он", "от", "так"},
+}
+
+// scriptLanguages maps scripts used by a single language (or a dominant one) to its code
+var scriptLanguages = []struct {
+	table *unicode.RangeTable
+	lang  string
+}{
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Hangul, "ko"},
+	{unicode.Han, "zh"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Greek, "el"},
+	{unicode.Thai, "th"},
+}
+
+// ContentMetrics describes the main text content of a page
+type ContentMetrics struct {
+	WordCount     int
+	SentenceCount int
+	ReadingEase   float64 // Flesch reading ease, 0 (hard) to 100 (easy); calibrated for English
+	TextHTMLRatio float64 // Visible text as a percentage of the HTML size
+	Language      string  // Detected ISO 639-1 code, empty when unknown
+	Fingerprint   uint64  // SimHash of the main text, 0 when the text is too short
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+// extractContentMetrics strips boilerplate and computes text metrics of the main content
+func extractContentMetrics(doc *goquery.Document, htmlSize int64) ContentMetrics {
+	metrics := ContentMetrics{}
+
+	body := doc.Find("body").Clone()
+	body.Find("script, style, noscript, template").Remove()
+	visibleText := strings.TrimSpace(SanitizeText(strings.Join(textBlocks(body), " ")))
+	if htmlSize > 0 {
+		metrics.TextHTMLRatio = math.Round(float64(len(visibleText))/float64(htmlSize)*1000) / 10
+	}
+
+	// Prefer the marked up main content, fall back to the body without page chrome
+	content := doc.Find("main, [role=main]").First().Clone()
+	if content.Length() == 0 {
+		content = doc.Find("body").Clone()
+	}
+	content.Find(boilerplateSelector).Remove()
+
+	blocks := textBlocks(content)
+	syllables := 0
+	var words []string
+	for _, block := range blocks {
+		blockWords := strings.Fields(block)
+		if len(blockWords) == 0 {
+			continue
+		}
+		words = append(words, blockWords...)
+		metrics.SentenceCount += countSentences(block)
+		for _, word := range blockWords {
+			syllables += countSyllables(word)
+		}
+	}
+
+	metrics.WordCount = len(words)
+	if metrics.WordCount > 0 && metrics.SentenceCount > 0 {
+		ease := 206.835 - 1.015*float64(metrics.WordCount)/float64(metrics.SentenceCount) -
+			84.6*float64(syllables)/float64(metrics.WordCount)
+		metrics.ReadingEase = math.Round(math.Max(0, math.Min(100, ease))*10) / 10
+	}
+	metrics.Language = detectLanguage(words)
+	metrics.Fingerprint = SimHash(words)
+
+	return metrics
+}
+
+// textBlocks returns the text of the selection split at block element boundaries
+func textBlocks(sel *goquery.Selection) []string {
+	var blocks []string
+	var current strings.Builder
+
+	var walk func(*goquery.Selection)
+	walk = func(s *goquery.Selection) {
+		s.Contents().Each(func(i int, node *goquery.Selection) {
+			name := goquery.NodeName(node)
+			switch {
+			case name == "#text":
+				current.WriteString(node.Text())
+			case blockElements[name]:
+				blocks = append(blocks, current.String())
+				current.Reset()
+				walk(node)
+				blocks = append(blocks, current.String())
+				current.Reset()
+			default:
+				walk(node)
+			}
+		})
+	}
+	walk(sel)
+	blocks = append(blocks, current.String())
+
+	nonEmpty := blocks[:0]
+	for _, block := range blocks {
+		if block = strings.TrimSpace(block); block != "" {
+			nonEmpty = append(nonEmpty, block)
+		}
+	}
+	return nonEmpty
+}
+
+// countSentences counts the sentences of a text block; a block without end punctuation,
+// such as a heading or list item, counts as one sentence
+func countSentences(block string) int {
+	count := 0
+	inTerminator := false
+	hasText := false
+	for _, r := range block {
+		switch {
+		case r == '.' || r == '!' || r == '?' || r == '。' || r == '！' || r == '？':
+			if hasText && !inTerminator {
+				count++
+			}
+			inTerminator = true
+			hasText = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			inTerminator = false
+			hasText = true
+		}
+	}
+	if hasText {
+		count++
+	}
+	if count == 0 {
+		count = 1
+	}
+	return count
+}
+
+// countSyllables estimates the syllables of an English word from its vowel groups
+func countSyllables(word string) int {
+	word = strings.ToLower(strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) }))
+	if word == "" {
+		return 0
+	}
+
+	count := 0
+	previousVowel := false
+	for _, r := range word {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !previousVowel {
+			count++
+		}
+		previousVowel = vowel
+	}
+
+	// Silent trailing e, as in "make", but not "le" as in "table"
+	if strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") && count > 1 {
+		count--
+	}
+	if count == 0 {
+		count = 1
+	}
+	return count
+}
+
+// detectLanguage guesses the language of the words from their script or from stopwords
+func detectLanguage(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+
+	// Scripts used by a single language decide on their own
+	scriptCounts := make(map[string]int)
+	letters := 0
+	for _, word := range words {
+		for _, r := range word {
+			if !unicode.IsLetter(r) {
+				continue
+			}
+			letters++
+			for _, script := range scriptLanguages {
+				if unicode.Is(script.table, r) {
+					scriptCounts[script.lang]++
+					break
+				}
+			}
+		}
+	}
+	// Japanese text mixes kana with Han characters
+	if scriptCounts["ja"] > 0 {
+		scriptCounts["ja"] += scriptCounts["zh"]
+	}
+	bestScript, bestScriptCount := "", 0
+	for lang, count := range scriptCounts {
+		if count > bestScriptCount || (count == bestScriptCount && lang < bestScript) {
+			bestScript, bestScriptCount = lang, count
+		}
+	}
+	if letters > 0 && bestScriptCount*2 > letters {
+		return bestScript
+	}
+
+	stopwordLanguages := make(map[string][]string)
+	for lang, stopwords := range languageStopwords {
+		for _, stopword := range stopwords {
+			stopwordLanguages[stopword] = append(stopwordLanguages[stopword], lang)
+		}
+	}
+
+	scores := make(map[string]int)
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) }))
+		for _, lang := range stopwordLanguages[word] {
+			scores[lang]++
+		}
+	}
+
+	bestLang, bestScore := "", 0
+	for lang, score := range scores {
+		if score > bestScore || (score == bestScore && lang < bestLang) {
+			bestLang, bestScore = lang, score
+		}
+	}
+	if bestScore < minLanguageMatches {
+		return ""
+	}
+	return bestLang
+}
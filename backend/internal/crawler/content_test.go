@@ -0,0 +1,71 @@
+package crawler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractContentMetrics(t *testing.T) {
+	html := `<html><head><style>body { color: red; }</style></head><body>
+		<header><a href="/">Home</a> <a href="/blog">Blog</a></header>
+		<nav><ul><li>Products</li><li>Pricing</li></ul></nav>
+		<main>
+			<h1>The cat and the hat</h1>
+			<p>The cat sat on the mat. It was a good day for the cat!</p>
+			<p>Is this the end of the story? No, it is not.</p>
+			<script>var tracking = "lots of words that should not count";</script>
+		</main>
+		<footer>Copyright and legal notices</footer>
+	</body></html>`
+
+	result, err := ParseHTML(strings.NewReader(html), "https://example.com/")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	content := result.Content
+	if content.WordCount != 30 {
+		t.Errorf("Expected 30 words of main content, got %d", content.WordCount)
+	}
+	// Heading plus two sentences in each paragraph
+	if content.SentenceCount != 5 {
+		t.Errorf("Expected 5 sentences, got %d", content.SentenceCount)
+	}
+	if content.ReadingEase < 90 {
+		t.Errorf("Expected very easy reading ease for short words and sentences, got %.1f", content.ReadingEase)
+	}
+	if content.TextHTMLRatio <= 0 || content.TextHTMLRatio >= 50 {
+		t.Errorf("Expected a text-to-HTML ratio between 0 and 50%%, got %.1f", content.TextHTMLRatio)
+	}
+	if content.Language != "en" {
+		t.Errorf("Expected language en, got %q", content.Language)
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"Der Hund und die Katze sind nicht auf dem Dach, das ist auch gut so.", "de"},
+		{"Le chat est dans la maison et les enfants sont dans le jardin pour la journée.", "fr"},
+		{"El perro y el gato están en la casa, y los niños juegan en el jardín con una pelota.", "es"},
+		{"これは日本語の文章です。ひらがなと漢字が混ざっています。", "ja"},
+		{"Short text", ""},
+	}
+
+	for _, test := range tests {
+		if lang := detectLanguage(strings.Fields(test.text)); lang != test.expected {
+			t.Errorf("detectLanguage(%q) = %q, expected %q", test.text, lang, test.expected)
+		}
+	}
+}
+
+func TestCountSyllables(t *testing.T) {
+	tests := map[string]int{"cat": 1, "table": 2, "make": 1, "reading": 2, "beautiful": 3, "the": 1, "42": 0}
+	for word, expected := range tests {
+		if count := countSyllables(word); count != expected {
+			t.Errorf("countSyllables(%q) = %d, expected %d", word, count, expected)
+		}
+	}
+}
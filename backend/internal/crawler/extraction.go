@@ -0,0 +1,190 @@
+package crawler
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+)
+
+// Extraction rule types
+const (
+	ExtractCSS   = "css"   // Expression is a CSS selector
+	ExtractRegex = "regex" // Expression is a regular expression matched against the HTML
+)
+
+// Extraction modes
+const (
+	ExtractSingle = "single" // Keep the first match
+	ExtractList   = "list"   // Keep every match
+)
+
+// Limits on extraction rules and extracted values
+const (
+	maxExtractionExpressionLength = 1000
+	maxExtractedValues            = 100
+	maxExtractedValueLength       = 1000
+)
+
+// ExtractionRule reads values from pages, such as a price or a build version.
+// An empty host suffix and path pattern apply the rule to every page it is given.
+type ExtractionRule struct {
+	ID          uint
+	Name        string
+	Type        string // ExtractCSS or ExtractRegex
+	Expression  string
+	Attribute   string // Attribute read from CSS matches, empty for their text
+	Mode        string // ExtractSingle or ExtractList
+	HostSuffix  string // Limits the rule to a host and its subdomains
+	PathPattern string // Glob limiting the rule to matching paths, e.g. /products/**
+}
+
+// Extraction holds the values a rule extracted from a page
+type Extraction struct {
+	RuleID uint
+	Name   string
+	Mode   string
+	Values []string // Empty when nothing matched
+}
+
+// compiledExtractionRule is a validated rule ready to run
+type compiledExtractionRule struct {
+	ExtractionRule
+	selector cascadia.Selector
+	regex    *regexp.Regexp
+	path     *regexp.Regexp
+}
+
+// ValidateExtractionRule checks the rule type, mode, expression and path pattern
+func ValidateExtractionRule(rule ExtractionRule) error {
+	_, err := compileExtractionRule(rule)
+	return err
+}
+
+// compileExtractionRule normalizes a rule and compiles its expression and path pattern
+func compileExtractionRule(rule ExtractionRule) (compiledExtractionRule, error) {
+	compiled := compiledExtractionRule{ExtractionRule: rule}
+
+	if strings.TrimSpace(rule.Name) == "" {
+		return compiled, fmt.Errorf("invalid extraction rule: name is required")
+	}
+	if rule.Mode == "" {
+		compiled.Mode = ExtractSingle
+	} else if rule.Mode != ExtractSingle && rule.Mode != ExtractList {
+		return compiled, fmt.Errorf("invalid extraction mode: %q", rule.Mode)
+	}
+
+	if utf8.RuneCountInString(rule.Expression) > maxExtractionExpressionLength {
+		return compiled, fmt.Errorf("invalid extraction rule: expression exceeds %d characters", maxExtractionExpressionLength)
+	}
+
+	switch rule.Type {
+	case ExtractCSS:
+		selector, err := cascadia.Compile(rule.Expression)
+		if err != nil {
+			return compiled, fmt.Errorf("invalid CSS selector %q: %w", rule.Expression, err)
+		}
+		compiled.selector = selector
+	case ExtractRegex:
+		regex, err := regexp.Compile(rule.Expression)
+		if err != nil {
+			return compiled, fmt.Errorf("invalid regular expression %q: %w", rule.Expression, err)
+		}
+		compiled.regex = regex
+	default:
+		return compiled, fmt.Errorf("invalid extraction type: %q", rule.Type)
+	}
+
+	compiled.HostSuffix = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(rule.HostSuffix)), ".")
+	if rule.PathPattern != "" {
+		path, err := regexp.Compile(globToRegexp(rule.PathPattern))
+		if err != nil {
+			return compiled, fmt.Errorf("invalid path pattern %q: %w", rule.PathPattern, err)
+		}
+		compiled.path = path
+	}
+
+	return compiled, nil
+}
+
+// matches reports whether the rule applies to a page
+func (r *compiledExtractionRule) matches(pageURL *url.URL) bool {
+	host := strings.ToLower(pageURL.Hostname())
+	if r.HostSuffix != "" && host != r.HostSuffix && !strings.HasSuffix(host, "."+r.HostSuffix) {
+		return false
+	}
+
+	path := pageURL.Path
+	if path == "" {
+		path = "/"
+	}
+	return r.path == nil || r.path.MatchString(path)
+}
+
+// ExtractValues runs the rules that apply to the page against its document. Invalid rules
+// are skipped, as rules are validated when they are saved.
+func ExtractValues(doc *goquery.Document, pageURL string, rules []ExtractionRule) []Extraction {
+	extractions := []Extraction{}
+	if doc == nil || len(rules) == 0 {
+		return extractions
+	}
+
+	parsedURL, err := url.Parse(pageURL)
+	if err != nil {
+		return extractions
+	}
+
+	var html string
+	for _, rule := range rules {
+		compiled, err := compileExtractionRule(rule)
+		if err != nil || !compiled.matches(parsedURL) {
+			continue
+		}
+
+		limit := maxExtractedValues
+		if compiled.Mode == ExtractSingle {
+			limit = 1
+		}
+
+		values := []string{}
+		if compiled.selector != nil {
+			doc.FindMatcher(compiled.selector).EachWithBreak(func(i int, s *goquery.Selection) bool {
+				value, ok := s.Text(), true
+				if compiled.Attribute != "" {
+					value, ok = s.Attr(compiled.Attribute)
+				}
+				if value = strings.Join(strings.Fields(value), " "); ok && value != "" {
+					values = append(values, truncateRunes(value, maxExtractedValueLength))
+				}
+				return len(values) < limit
+			})
+		} else {
+			if html == "" {
+				html, _ = doc.Html()
+			}
+			for _, match := range compiled.regex.FindAllStringSubmatch(html, limit) {
+				// The first capture group is the value when the expression has one
+				value := match[0]
+				if len(match) > 1 {
+					value = match[1]
+				}
+				if value = strings.TrimSpace(value); value != "" {
+					values = append(values, truncateRunes(value, maxExtractedValueLength))
+				}
+			}
+		}
+
+		extractions = append(extractions, Extraction{
+			RuleID: rule.ID,
+			Name:   compiled.Name,
+			Mode:   compiled.Mode,
+			Values: values,
+		})
+	}
+
+	return extractions
+}
@@ -0,0 +1,88 @@
+package crawler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractValues(t *testing.T) {
+	html := `<html><body>
+		<div class="product">
+			<span class="price" data-amount="1299.00">$1,299.00</span>
+			<link itemprop="availability" href="https://schema.org/InStock">
+			<ul class="sizes"><li>S</li><li>M</li><li> L </li></ul>
+		</div>
+		<footer>Build 4.12.0-rc1 &middot; © Example</footer>
+	</body></html>`
+
+	parseResult, err := ParseHTML(strings.NewReader(html), "https://shop.example.com/products/widget")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	rules := []ExtractionRule{
+		{ID: 1, Name: "price", Type: ExtractCSS, Expression: ".price"},
+		{ID: 2, Name: "amount", Type: ExtractCSS, Expression: ".price", Attribute: "data-amount"},
+		{ID: 3, Name: "stock", Type: ExtractCSS, Expression: "[itemprop=availability]", Attribute: "href"},
+		{ID: 4, Name: "sizes", Type: ExtractCSS, Expression: ".sizes li", Mode: ExtractList},
+		{ID: 5, Name: "build", Type: ExtractRegex, Expression: `Build ([\d.]+(?:-\w+)?)`},
+		{ID: 6, Name: "sku", Type: ExtractCSS, Expression: ".sku"},
+		{ID: 7, Name: "blog only", Type: ExtractCSS, Expression: "h1", PathPattern: "/blog/**"},
+		{ID: 8, Name: "other shop", Type: ExtractCSS, Expression: "h1", HostSuffix: "example.org"},
+		{ID: 9, Name: "product pages", Type: ExtractCSS, Expression: ".price", HostSuffix: "example.com", PathPattern: "/products/*"},
+	}
+
+	extractions := ExtractValues(parseResult.Document, "https://shop.example.com/products/widget", rules)
+
+	expected := map[string][]string{
+		"price":         {"$1,299.00"},
+		"amount":        {"1299.00"},
+		"stock":         {"https://schema.org/InStock"},
+		"sizes":         {"S", "M", "L"},
+		"build":         {"4.12.0-rc1"},
+		"sku":           {},
+		"product pages": {"$1,299.00"},
+	}
+	if len(extractions) != len(expected) {
+		t.Fatalf("Expected %d extractions, got %d: %+v", len(expected), len(extractions), extractions)
+	}
+	for _, extraction := range extractions {
+		want, ok := expected[extraction.Name]
+		if !ok {
+			t.Errorf("Unexpected extraction %q", extraction.Name)
+			continue
+		}
+		if strings.Join(extraction.Values, "|") != strings.Join(want, "|") {
+			t.Errorf("%s: expected %v, got %v", extraction.Name, want, extraction.Values)
+		}
+	}
+	if extractions[0].RuleID != 1 || extractions[0].Mode != ExtractSingle {
+		t.Errorf("Expected the rule ID and default mode to be kept, got %+v", extractions[0])
+	}
+}
+
+func TestValidateExtractionRule(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  ExtractionRule
+		valid bool
+	}{
+		{"valid css", ExtractionRule{Name: "price", Type: ExtractCSS, Expression: "div.price > span"}, true},
+		{"valid regex list", ExtractionRule{Name: "ids", Type: ExtractRegex, Expression: `id-(\d+)`, Mode: ExtractList}, true},
+		{"missing name", ExtractionRule{Type: ExtractCSS, Expression: "div"}, false},
+		{"invalid selector", ExtractionRule{Name: "x", Type: ExtractCSS, Expression: "div[["}, false},
+		{"invalid regex", ExtractionRule{Name: "x", Type: ExtractRegex, Expression: "(unclosed"}, false},
+		{"unknown type", ExtractionRule{Name: "x", Type: "xpath", Expression: "//div"}, false},
+		{"unknown mode", ExtractionRule{Name: "x", Type: ExtractCSS, Expression: "div", Mode: "all"}, false},
+		{"expression too long", ExtractionRule{Name: "x", Type: ExtractRegex, Expression: strings.Repeat("a", maxExtractionExpressionLength+1)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateExtractionRule(tt.rule)
+			if (err == nil) != tt.valid {
+				t.Errorf("Expected valid=%v, got error %v", tt.valid, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package crawler
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Form types
+const (
+	FormLogin      = "login"
+	FormSignup     = "signup"
+	FormSearch     = "search"
+	FormNewsletter = "newsletter"
+	FormContact    = "contact"
+	FormPayment    = "payment"
+	FormOther      = "other"
+)
+
+// Form issue types
+const (
+	FormIssuePasswordOverHTTP    = "password_over_http"    // Password submitted without TLS
+	FormIssuePasswordCrossOrigin = "password_cross_origin" // Password submitted to another origin
+)
+
+// Limits on the forms recorded per page
+const (
+	maxForms           = 50
+	maxFieldsPerForm   = 100
+	maxFormIDLength    = 255
+	maxFieldNameLength = 255 // Field names and autocomplete tokens
+)
+
+// csrfFieldName matches the names frameworks give their anti-CSRF token fields
+var csrfFieldName = regexp.MustCompile(`(?i)(csrf|xsrf|authenticity_token|requestverificationtoken|^_token$|_wpnonce|form_key|^nonce$)`)
+
+// Keywords found in the action, id, class or name of a form, by form type
+var (
+	signupKeywords     = []string{"register", "signup", "sign-up", "sign_up", "join", "create-account", "create_account"}
+	searchKeywords     = []string{"search"}
+	newsletterKeywords = []string{"newsletter", "subscribe", "mailchimp", "list-manage"}
+	contactKeywords    = []string{"contact", "enquiry", "inquiry", "feedback", "message"}
+	paymentKeywords    = []string{"checkout", "payment", "billing"}
+)
+
+// searchFieldNames are the usual names of search query fields
+var searchFieldNames = map[string]bool{"q": true, "query": true, "s": true, "search": true, "keyword": true, "keywords": true, "term": true}
+
+// paymentFieldName matches card data field names
+var paymentFieldName = regexp.MustCompile(`(?i)(card.?number|cc.?num|cvv|cvc|card.?code|expiry|exp.?date)`)
+
+// FormField is an input, select or textarea submitted with a form
+type FormField struct {
+	Name         string
+	Type         string // Input type, or select or textarea
+	Autocomplete string
+	Required     bool
+}
+
+// FormInfo describes a form found on a page
+type FormInfo struct {
+	Position     int // Order of the form on the page, from 1
+	ID           string
+	Method       string // GET, POST or DIALOG
+	Action       string // Resolved submission URL, empty when the form isn't submitted over HTTP
+	Secure       bool   // Submits over HTTPS
+	CrossOrigin  bool   // Submits to another origin than the page
+	Type         string
+	HasPassword  bool
+	HasCSRFToken bool
+	Fields       []FormField
+}
+
+// FormIssue is a security problem with a form
+type FormIssue struct {
+	Type     string
+	Position int // Position of the form on the page
+	URL      string
+	Message  string
+}
+
+// extractForms catalogues the forms of a page and flags password forms submitted insecurely
+func extractForms(doc *goquery.Document, baseURL *url.URL) ([]FormInfo, []FormIssue) {
+	forms := []FormInfo{}
+	issues := []FormIssue{}
+
+	doc.Find("form").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		if len(forms) >= maxForms {
+			return false
+		}
+
+		id := strings.TrimSpace(s.AttrOr("id", ""))
+		form := FormInfo{
+			Position: i + 1,
+			ID:       truncateRunes(id, maxFormIDLength),
+			Method:   strings.ToUpper(strings.TrimSpace(s.AttrOr("method", "get"))),
+		}
+		if form.Method != "POST" && form.Method != "DIALOG" {
+			form.Method = "GET"
+		}
+
+		// A missing or empty action submits to the page itself
+		rawAction := strings.TrimSpace(s.AttrOr("action", ""))
+		if rawAction == "" {
+			rawAction = baseURL.String()
+		}
+		if action := resolveURL(baseURL, rawAction); IsValidHTTPURL(action) {
+			if actionURL, err := url.Parse(action); err == nil {
+				form.Action = truncateRunes(action, maxStoredURLLength)
+				form.Secure = actionURL.Scheme == "https"
+				form.CrossOrigin = actionURL.Scheme != baseURL.Scheme || !strings.EqualFold(actionURL.Host, baseURL.Host)
+			}
+		}
+
+		// Fields inside the form, plus those associated through their form attribute
+		fields := s.Find("input, select, textarea")
+		if id != "" {
+			fields = fields.AddSelection(doc.Find(fmt.Sprintf("input[form=%q], select[form=%q], textarea[form=%q]", id, id, id)))
+		}
+		fields.Each(func(j int, field *goquery.Selection) {
+			name := strings.TrimSpace(field.AttrOr("name", ""))
+			fieldType := goquery.NodeName(field)
+			if fieldType == "input" {
+				fieldType = strings.ToLower(strings.TrimSpace(field.AttrOr("type", "text")))
+				if fieldType == "" {
+					fieldType = "text"
+				}
+			}
+
+			switch fieldType {
+			case "submit", "button", "reset", "image":
+				return
+			case "password":
+				form.HasPassword = true
+			case "hidden":
+				if csrfFieldName.MatchString(name) {
+					form.HasCSRFToken = true
+				}
+			}
+
+			if len(form.Fields) < maxFieldsPerForm {
+				_, required := field.Attr("required")
+				form.Fields = append(form.Fields, FormField{
+					Name:         truncateRunes(name, maxFieldNameLength),
+					Type:         truncateRunes(fieldType, maxFieldNameLength),
+					Autocomplete: truncateRunes(strings.ToLower(strings.TrimSpace(field.AttrOr("autocomplete", ""))), maxFieldNameLength),
+					Required:     required,
+				})
+			}
+		})
+
+		form.Type = classifyForm(s, form)
+
+		if form.HasPassword && form.Action != "" {
+			if !form.Secure {
+				issues = append(issues, FormIssue{
+					Type:     FormIssuePasswordOverHTTP,
+					Position: form.Position,
+					URL:      form.Action,
+					Message:  "Password form submits over unencrypted HTTP",
+				})
+			}
+			if form.CrossOrigin {
+				issues = append(issues, FormIssue{
+					Type:     FormIssuePasswordCrossOrigin,
+					Position: form.Position,
+					URL:      form.Action,
+					Message:  "Password form submits to another origin",
+				})
+			}
+		}
+
+		forms = append(forms, form)
+		return true
+	})
+
+	return forms, issues
+}
+
+// classifyForm guesses the purpose of a form from its fields, markup and submit button
+func classifyForm(s *goquery.Selection, form FormInfo) string {
+	passwords := 0
+	newPassword := false
+	emails, texts, textareas, others := 0, 0, 0, 0
+	searchField := false
+	for _, field := range form.Fields {
+		switch {
+		case strings.HasPrefix(field.Autocomplete, "cc-") || paymentFieldName.MatchString(field.Name):
+			return FormPayment
+		case field.Type == "password":
+			passwords++
+			newPassword = newPassword || field.Autocomplete == "new-password"
+		case field.Type == "search" || searchFieldNames[strings.ToLower(field.Name)]:
+			searchField = true
+		case field.Type == "email" || strings.Contains(strings.ToLower(field.Name), "email"):
+			emails++
+		case field.Type == "textarea":
+			textareas++
+		case field.Type == "text" || field.Type == "tel" || field.Type == "url":
+			texts++
+		case field.Type != "hidden" && field.Type != "checkbox":
+			others++
+		}
+	}
+
+	markup := strings.ToLower(strings.Join([]string{
+		s.AttrOr("action", ""), s.AttrOr("id", ""), s.AttrOr("class", ""), s.AttrOr("name", ""),
+		s.AttrOr("role", ""), s.Find("button, input[type=submit]").Text(), s.Find("input[type=submit]").AttrOr("value", ""),
+	}, " "))
+	mentions := func(keywords []string) bool {
+		for _, keyword := range keywords {
+			if strings.Contains(markup, keyword) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case mentions(paymentKeywords) && passwords == 0:
+		return FormPayment
+	case passwords > 1 || newPassword || (passwords > 0 && mentions(signupKeywords)):
+		return FormSignup
+	case passwords == 1:
+		return FormLogin
+	case searchField || mentions(searchKeywords):
+		return FormSearch
+	case emails == 1 && texts <= 1 && textareas == 0 && others == 0 && !mentions(contactKeywords):
+		return FormNewsletter
+	case mentions(newsletterKeywords):
+		return FormNewsletter
+	case textareas > 0 && (emails > 0 || mentions(contactKeywords)):
+		return FormContact
+	case mentions(contactKeywords) && emails > 0:
+		return FormContact
+	case mentions(signupKeywords) && emails > 0:
+		return FormSignup
+	}
+	return FormOther
+}
@@ -0,0 +1,135 @@
+package crawler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractForms(t *testing.T) {
+	html := `<html><body>
+		<form id="login" method="post" action="/session">
+			<input type="hidden" name="authenticity_token" value="abc">
+			<input type="email" name="email" autocomplete="username" required>
+			<input type="password" name="password" autocomplete="current-password" required>
+			<button type="submit">Sign in</button>
+		</form>
+		<form method="post" action="https://accounts.example.com/register">
+			<input type="text" name="name">
+			<input type="email" name="email">
+			<input type="password" name="password" autocomplete="new-password">
+			<input type="password" name="password_confirmation" autocomplete="new-password">
+		</form>
+		<form role="search" action="/search">
+			<input type="search" name="q">
+		</form>
+		<form action="https://list-manage.com/subscribe/post">
+			<input type="email" name="EMAIL" placeholder="Your email">
+			<input type="submit" value="Subscribe">
+		</form>
+		<form id="contact" method="POST">
+			<input type="text" name="name">
+			<input type="email" name="email">
+			<textarea name="message"></textarea>
+		</form>
+		<form action="/checkout/pay" method="post">
+			<input type="text" name="card" autocomplete="cc-number">
+			<input type="text" name="exp" autocomplete="cc-exp">
+		</form>
+		<input type="text" name="subject" form="contact">
+	</body></html>`
+
+	parseResult, err := ParseHTML(strings.NewReader(html), "https://example.com/page")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	forms := parseResult.Forms
+	expectedTypes := []string{FormLogin, FormSignup, FormSearch, FormNewsletter, FormContact, FormPayment}
+	if len(forms) != len(expectedTypes) {
+		t.Fatalf("Expected %d forms, got %d", len(expectedTypes), len(forms))
+	}
+	for i, expected := range expectedTypes {
+		if forms[i].Type != expected {
+			t.Errorf("Form %d: expected type %s, got %s", i+1, expected, forms[i].Type)
+		}
+	}
+
+	login := forms[0]
+	if login.Method != "POST" || login.Action != "https://example.com/session" || !login.Secure || login.CrossOrigin {
+		t.Errorf("Unexpected login form target: %+v", login)
+	}
+	if !login.HasPassword || !login.HasCSRFToken || len(login.Fields) != 3 {
+		t.Errorf("Unexpected login form fields: %+v", login)
+	}
+	if field := login.Fields[2]; field.Type != "password" || field.Autocomplete != "current-password" || !field.Required {
+		t.Errorf("Unexpected password field: %+v", field)
+	}
+
+	if !forms[1].CrossOrigin || forms[1].HasCSRFToken {
+		t.Errorf("Expected the signup form to post cross-origin without a CSRF token: %+v", forms[1])
+	}
+	if forms[2].Method != "GET" {
+		t.Errorf("Expected the search form to default to GET, got %s", forms[2].Method)
+	}
+
+	contact := forms[4]
+	if contact.Action != "https://example.com/page" || len(contact.Fields) != 4 {
+		t.Errorf("Expected the contact form to post to the page with its associated field: %+v", contact)
+	}
+
+	// Only the signup password form posts to another origin
+	if len(parseResult.FormIssues) != 1 || parseResult.FormIssues[0].Type != FormIssuePasswordCrossOrigin ||
+		parseResult.FormIssues[0].Position != 2 {
+		t.Errorf("Unexpected form issues: %+v", parseResult.FormIssues)
+	}
+}
+
+func TestExtractFormsPasswordOverHTTP(t *testing.T) {
+	html := `<html><body>
+		<form method="post" action="http://example.com/login">
+			<input type="text" name="user">
+			<input type="password" name="pass">
+		</form>
+		<form action="javascript:void(0)">
+			<input type="password" name="pin">
+		</form>
+	</body></html>`
+
+	parseResult, err := ParseHTML(strings.NewReader(html), "https://example.com/")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	// The form downgrades to HTTP, which also changes its origin; script-handled forms aren't flagged
+	issues := parseResult.FormIssues
+	if len(issues) != 2 || issues[0].Type != FormIssuePasswordOverHTTP || issues[1].Type != FormIssuePasswordCrossOrigin {
+		t.Errorf("Unexpected form issues: %+v", issues)
+	}
+	if parseResult.Forms[1].Action != "" || parseResult.Forms[1].Type != FormLogin {
+		t.Errorf("Unexpected script-handled form: %+v", parseResult.Forms[1])
+	}
+}
+
+func TestExtractFormsBoundsStoredValues(t *testing.T) {
+	longID := strings.Repeat("f", maxFormIDLength+50)
+	longAction := "/submit?token=" + strings.Repeat("a", maxStoredURLLength)
+	html := `<html><body>
+		<form id="` + longID + `" method="post" action="` + longAction + `">
+			<input type="password" name="pass">
+		</form>
+		<input type="email" name="email" form="` + longID + `">
+	</body></html>`
+
+	parseResult, err := ParseHTML(strings.NewReader(html), "https://example.com/")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	form := parseResult.Forms[0]
+	if len(form.ID) != maxFormIDLength || len(form.Action) != maxStoredURLLength {
+		t.Errorf("Expected the id and action to be cut to their columns, got %d and %d characters", len(form.ID), len(form.Action))
+	}
+	if len(form.Fields) != 2 {
+		t.Errorf("Expected the field associated through the full id, got %+v", form.Fields)
+	}
+}
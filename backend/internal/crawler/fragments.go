@@ -0,0 +1,156 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ResourceAnchor marks a broken link whose #fragment has no matching element
+const ResourceAnchor = "anchor"
+
+// maxFragmentPages limits how many internal pages are fetched to validate fragments
+const maxFragmentPages = 50
+
+// FragmentRef is a link to a #fragment on this page or on another internal page
+type FragmentRef struct {
+	URL      string // Target page without the fragment
+	Fragment string
+	SamePage bool
+}
+
+// extractAnchorTargets collects the ids and a[name] values that fragments can point to
+func extractAnchorTargets(doc *goquery.Document) map[string]bool {
+	targets := make(map[string]bool)
+
+	doc.Find("[id]").Each(func(i int, s *goquery.Selection) {
+		if id, _ := s.Attr("id"); id != "" {
+			targets[id] = true
+		}
+	})
+	doc.Find("a[name]").Each(func(i int, s *goquery.Selection) {
+		if name, _ := s.Attr("name"); name != "" {
+			targets[name] = true
+		}
+	})
+
+	return targets
+}
+
+// extractFragments collects links with fragments that point at this page or other internal pages
+func extractFragments(doc *goquery.Document, baseURL *url.URL) []FragmentRef {
+	fragments := []FragmentRef{}
+	seen := make(map[string]bool)
+	pageURL := stripFragment(baseURL)
+
+	doc.Find("a[href]").Each(func(i int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		linkURL, err := url.Parse(strings.TrimSpace(href))
+		if err != nil {
+			return
+		}
+
+		resolved := baseURL.ResolveReference(linkURL)
+		if !isValidatableFragment(resolved.Fragment) || !strings.EqualFold(resolved.Host, baseURL.Host) {
+			return
+		}
+		if resolved.Scheme != "http" && resolved.Scheme != "https" {
+			return
+		}
+
+		target := stripFragment(resolved)
+		key := target + "#" + resolved.Fragment
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+
+		fragments = append(fragments, FragmentRef{
+			URL:      target,
+			Fragment: resolved.Fragment,
+			SamePage: target == pageURL,
+		})
+	})
+
+	return fragments
+}
+
+// validateFragments reports fragments that don't resolve to an element in the target document.
+// Target pages excluded by the filter are not fetched.
+func (c *CrawlerService) validateFragments(ctx context.Context, parseResult *ParseResult, checks map[string]*LinkCheckResult, filter *LinkFilter) []BrokenLinkInfo {
+	var dangling []BrokenLinkInfo
+
+	// Group fragments by target page so each page is fetched once
+	var pages []string
+	byPage := make(map[string][]FragmentRef)
+	for _, fragment := range parseResult.Fragments {
+		if fragment.SamePage {
+			if !parseResult.AnchorTargets[fragment.Fragment] {
+				dangling = append(dangling, danglingAnchor(fragment, 0))
+			}
+			continue
+		}
+		if filter.Excludes(fragment.URL) {
+			continue
+		}
+		if _, exists := byPage[fragment.URL]; !exists {
+			pages = append(pages, fragment.URL)
+		}
+		byPage[fragment.URL] = append(byPage[fragment.URL], fragment)
+	}
+
+	for i, page := range pages {
+		if i >= maxFragmentPages || ctx.Err() != nil {
+			break
+		}
+
+		// Pages that failed the link check are already reported as broken
+		if check, exists := checks[page]; exists && check.IsBroken() {
+			continue
+		}
+
+		target, err := c.fetchAndParse(ctx, page)
+		if err != nil {
+			continue
+		}
+
+		for _, fragment := range byPage[page] {
+			if !target.AnchorTargets[fragment.Fragment] {
+				dangling = append(dangling, danglingAnchor(fragment, 200))
+			}
+		}
+	}
+
+	return dangling
+}
+
+// danglingAnchor builds the broken link entry for a fragment without a target
+func danglingAnchor(fragment FragmentRef, statusCode int) BrokenLinkInfo {
+	return BrokenLinkInfo{
+		URL:          fragment.URL + "#" + url.PathEscape(fragment.Fragment),
+		StatusCode:   statusCode,
+		Error:        fmt.Sprintf("Anchor #%s not found on target page", fragment.Fragment),
+		ResourceType: ResourceAnchor,
+		Class:        LinkClassBroken,
+	}
+}
+
+// isValidatableFragment skips fragments that never map to elements, such as
+// "#", "#top" and client-side routes ("#/path", "#!/path")
+func isValidatableFragment(fragment string) bool {
+	if fragment == "" || strings.EqualFold(fragment, "top") {
+		return false
+	}
+	return !strings.HasPrefix(fragment, "/") && !strings.HasPrefix(fragment, "!")
+}
+
+// stripFragment returns the URL without its fragment
+func stripFragment(u *url.URL) string {
+	clean := *u
+	clean.Fragment = ""
+	clean.RawFragment = ""
+	return clean.String()
+}
@@ -0,0 +1,92 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestValidateFragments(t *testing.T) {
+	var excludedRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/logout":
+			atomic.AddInt32(&excludedRequests, 1)
+			w.Write([]byte(`<html><body></body></html>`))
+		case "/docs":
+			w.Write([]byte(`<html><body><h2 id="install">Install</h2><a name="usage"></a></body></html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	pageHTML := `
+<html>
+<body>
+    <h2 id="intro">Intro</h2>
+    <a href="#intro">Intro</a>
+    <a href="#missing">Missing</a>
+    <a href="#">Top</a>
+    <a href="#/app/route">Client route</a>
+    <a href="/docs#install">Install</a>
+    <a href="/docs#usage">Usage</a>
+    <a href="/docs#configure">Configure</a>
+    <a href="/gone#section">Gone</a>
+    <a href="/logout#confirm">Log out</a>
+    <a href="https://external.com/page#anything">External</a>
+</body>
+</html>`
+
+	parseResult, err := ParseHTML(strings.NewReader(pageHTML), server.URL+"/page")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	if len(parseResult.Fragments) != 7 {
+		t.Fatalf("Expected 7 fragment links to validate, got %d: %+v", len(parseResult.Fragments), parseResult.Fragments)
+	}
+
+	// The missing page is already reported by the regular link check
+	checks := map[string]*LinkCheckResult{
+		server.URL + "/gone": {URL: server.URL + "/gone", StatusCode: 404, Error: "HTTP 404"},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Pages excluded by the filter are never fetched
+	filter, err := NewLinkFilter([]LinkFilterRule{{Action: FilterExclude, PathPattern: "/logout"}})
+	if err != nil {
+		t.Fatalf("Failed to build filter: %v", err)
+	}
+
+	crawler := NewCrawlerService(nil)
+	dangling := crawler.validateFragments(ctx, parseResult, checks, filter)
+	if excludedRequests != 0 {
+		t.Errorf("Expected the excluded page not to be fetched, got %d requests", excludedRequests)
+	}
+
+	got := make(map[string]bool)
+	for _, broken := range dangling {
+		if broken.ResourceType != ResourceAnchor {
+			t.Errorf("Expected resource type %s, got %s", ResourceAnchor, broken.ResourceType)
+		}
+		got[strings.TrimPrefix(broken.URL, server.URL)] = true
+	}
+
+	want := []string{"/page#missing", "/docs#configure"}
+	if len(got) != len(want) {
+		t.Errorf("Expected %d dangling anchors, got %+v", len(want), got)
+	}
+	for _, url := range want {
+		if !got[url] {
+			t.Errorf("Expected dangling anchor %s", url)
+		}
+	}
+}
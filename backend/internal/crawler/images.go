@@ -0,0 +1,283 @@
+package crawler
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Image issue types
+const (
+	ImageIssueMissingAlt       = "missing_alt"
+	ImageIssueDecorativeMisuse = "decorative_misuse"
+	ImageIssueFilenameAlt      = "filename_alt"
+	ImageIssueOversized        = "oversized"
+	ImageIssueBroken           = "broken"
+)
+
+// MaxImageBytes is the size above which an image is reported as oversized
+const MaxImageBytes = 500 * 1024
+
+// Limits on the image inventory of a single page
+const (
+	maxImages            = 1000
+	maxImageAltLength    = 1000
+	maxDescriptorLength  = 20
+	maxFilenameAltLength = 100 // Alt text quoted in an issue message
+)
+
+// ImageInfo describes an image referenced by a page
+type ImageInfo struct {
+	URL        string
+	Element    string // "img" or "source"
+	Descriptor string // srcset descriptor such as "2x" or "640w", empty for src
+	Alt        string
+	HasAlt     bool
+	Width      int // Declared width attribute, 0 when missing
+	Height     int // Declared height attribute, 0 when missing
+	Lazy       bool
+	StatusCode int
+	Size       int64 // Content-Length reported by the server, -1 when unknown
+	Broken     bool
+}
+
+// ImageIssue describes an accessibility or performance problem with an image
+type ImageIssue struct {
+	Type    string
+	URL     string
+	Message string
+}
+
+// extractImages builds the image inventory of a document and reports markup problems
+func extractImages(doc *goquery.Document, baseURL *url.URL) ([]ImageInfo, []ImageIssue) {
+	images := []ImageInfo{}
+	issues := []ImageIssue{}
+	seen := make(map[string]bool)
+
+	add := func(image ImageInfo) {
+		if image.URL == "" || seen[image.URL] || len(images) >= maxImages {
+			return
+		}
+		seen[image.URL] = true
+		images = append(images, image)
+	}
+
+	doc.Find("img").Each(func(i int, s *goquery.Selection) {
+		alt, hasAlt := s.Attr("alt")
+		alt = strings.TrimSpace(alt)
+		loading, _ := s.Attr("loading")
+		width, _ := s.Attr("width")
+		height, _ := s.Attr("height")
+
+		base := ImageInfo{
+			Element: "img",
+			Alt:     truncateRunes(alt, maxImageAltLength),
+			HasAlt:  hasAlt,
+			Width:   parseDimension(width),
+			Height:  parseDimension(height),
+			Lazy:    strings.EqualFold(strings.TrimSpace(loading), "lazy"),
+			Size:    -1,
+		}
+
+		// Lazy-loading libraries keep the real source in data-src
+		src, _ := s.Attr("src")
+		if dataSrc, exists := s.Attr("data-src"); exists && (strings.TrimSpace(src) == "" || strings.HasPrefix(src, "data:")) {
+			src = dataSrc
+			base.Lazy = true
+		}
+
+		image := base
+		image.URL = resolveImageURL(baseURL, src)
+		add(image)
+		primaryURL := image.URL
+
+		srcset, _ := s.Attr("srcset")
+		for _, candidate := range parseSrcset(srcset) {
+			image := base
+			image.URL = resolveImageURL(baseURL, candidate.url)
+			image.Descriptor = candidate.descriptor
+			add(image)
+			if primaryURL == "" {
+				primaryURL = image.URL
+			}
+		}
+
+		// <picture> sources share the alt text of their <img>
+		s.ParentsFiltered("picture").First().Find("source[srcset]").Each(func(j int, source *goquery.Selection) {
+			sourceSrcset, _ := source.Attr("srcset")
+			for _, candidate := range parseSrcset(sourceSrcset) {
+				image := base
+				image.Element = "source"
+				image.URL = resolveImageURL(baseURL, candidate.url)
+				image.Descriptor = candidate.descriptor
+				add(image)
+			}
+		})
+
+		issues = append(issues, checkImageMarkup(s, primaryURL, alt, hasAlt)...)
+	})
+
+	return images, issues
+}
+
+// checkImageMarkup reports missing alt text and misuse of decorative images
+func checkImageMarkup(s *goquery.Selection, imageURL, alt string, hasAlt bool) []ImageIssue {
+	var issues []ImageIssue
+
+	role, _ := s.Attr("role")
+	role = strings.ToLower(strings.TrimSpace(role))
+	ariaHidden, _ := s.Attr("aria-hidden")
+	hidden := role == "presentation" || role == "none" || strings.EqualFold(ariaHidden, "true")
+
+	if !hasAlt && !hidden {
+		issues = append(issues, ImageIssue{
+			Type:    ImageIssueMissingAlt,
+			URL:     imageURL,
+			Message: "Image has no alt attribute",
+		})
+		return issues
+	}
+
+	// Decorative images must not carry alt text
+	if hidden && alt != "" {
+		issues = append(issues, ImageIssue{
+			Type:    ImageIssueDecorativeMisuse,
+			URL:     imageURL,
+			Message: "Image is marked decorative but has alt text",
+		})
+	}
+
+	// An image that is the only content of a link or button must describe it
+	if alt == "" {
+		control := s.Closest("a, button")
+		if control.Length() > 0 && SanitizeText(control.Text()) == "" && !hasAccessibleLabel(control) {
+			issues = append(issues, ImageIssue{
+				Type:    ImageIssueDecorativeMisuse,
+				URL:     imageURL,
+				Message: fmt.Sprintf("Image with empty alt is the only content of a <%s>", goquery.NodeName(control)),
+			})
+		}
+	}
+
+	// Alt text that is just the file name does not describe the image
+	if alt != "" && imageURL != "" {
+		if parsed, err := url.Parse(imageURL); err == nil {
+			fileName := path.Base(parsed.Path)
+			if strings.EqualFold(alt, fileName) || strings.EqualFold(alt, strings.TrimSuffix(fileName, path.Ext(fileName))) {
+				issues = append(issues, ImageIssue{
+					Type:    ImageIssueFilenameAlt,
+					URL:     imageURL,
+					Message: fmt.Sprintf("Alt text %q is the image file name", truncateRunes(alt, maxFilenameAltLength)),
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// applyImageChecks records link check outcomes on the images and reports broken and oversized ones
+func applyImageChecks(images []ImageInfo, checks map[string]*LinkCheckResult) []ImageIssue {
+	var issues []ImageIssue
+
+	for i := range images {
+		check, exists := checks[images[i].URL]
+		if !exists {
+			continue
+		}
+
+		images[i].StatusCode = check.StatusCode
+		images[i].Size = check.ContentLength
+		images[i].Broken = check.IsBroken()
+
+		if check.IsBroken() {
+			issues = append(issues, ImageIssue{
+				Type:    ImageIssueBroken,
+				URL:     images[i].URL,
+				Message: fmt.Sprintf("Image could not be loaded: %s", check.Error),
+			})
+			continue
+		}
+
+		if check.ContentLength > MaxImageBytes {
+			issues = append(issues, ImageIssue{
+				Type:    ImageIssueOversized,
+				URL:     images[i].URL,
+				Message: fmt.Sprintf("Image is %d KB (maximum %d KB)", check.ContentLength/1024, MaxImageBytes/1024),
+			})
+		}
+	}
+
+	return issues
+}
+
+// ImageURLs returns the distinct image URLs of an inventory
+func ImageURLs(images []ImageInfo) []string {
+	var urls []string
+	for _, image := range images {
+		urls = append(urls, image.URL)
+	}
+	return DeduplicateLinks(urls)
+}
+
+// srcsetCandidate is a single entry of a srcset attribute
+type srcsetCandidate struct {
+	url        string
+	descriptor string
+}
+
+// parseSrcset splits a srcset attribute into its image candidates
+func parseSrcset(srcset string) []srcsetCandidate {
+	var candidates []srcsetCandidate
+
+	for _, part := range strings.Split(srcset, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+
+		candidate := srcsetCandidate{url: fields[0]}
+		if len(fields) > 1 {
+			candidate.descriptor = truncateRunes(fields[1], maxDescriptorLength)
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates
+}
+
+// resolveImageURL resolves an image source, ignoring inline data URIs and URLs too long to store
+func resolveImageURL(baseURL *url.URL, src string) string {
+	src = strings.TrimSpace(src)
+	if src == "" || strings.HasPrefix(strings.ToLower(src), "data:") {
+		return ""
+	}
+	if resolved := resolveURL(baseURL, src); len(resolved) <= maxStoredURLLength {
+		return resolved
+	}
+	return ""
+}
+
+// parseDimension parses a width or height attribute such as "640" or "640px"
+func parseDimension(value string) int {
+	value = strings.TrimSuffix(strings.TrimSpace(value), "px")
+	dimension, err := strconv.Atoi(value)
+	if err != nil || dimension < 0 {
+		return 0
+	}
+	return dimension
+}
+
+// hasAccessibleLabel checks whether an element is labelled through ARIA or a title
+func hasAccessibleLabel(s *goquery.Selection) bool {
+	for _, attr := range []string{"aria-label", "aria-labelledby", "title"} {
+		if value, exists := s.Attr(attr); exists && strings.TrimSpace(value) != "" {
+			return true
+		}
+	}
+	return false
+}
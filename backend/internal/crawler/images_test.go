@@ -0,0 +1,150 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestExtractImages(t *testing.T) {
+	sampleHTML := `
+<html>
+<body>
+    <img src="/hero.jpg" alt="Team photo" width="800" height="600px" loading="lazy"
+         srcset="/hero-small.jpg 480w, /hero-large.jpg 1600w">
+    <img src="data:image/gif;base64,R0lGOD" data-src="/lazy.png" alt="Chart">
+    <img src="/no-alt.png">
+    <a href="/home"><img src="/logo.svg" alt=""></a>
+    <img src="/divider.gif" role="presentation" alt="Decorative line">
+    <img src="/photo_123.jpg" alt="photo_123.jpg">
+    <picture>
+        <source srcset="/pic.webp 1x, /pic@2x.webp 2x" type="image/webp">
+        <img src="/pic.jpg" alt="Picture">
+    </picture>
+</body>
+</html>`
+
+	result, err := ParseHTML(strings.NewReader(sampleHTML), "https://example.com/")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	images := make(map[string]ImageInfo)
+	for _, image := range result.Images {
+		images[strings.TrimPrefix(image.URL, "https://example.com")] = image
+	}
+
+	hero := images["/hero.jpg"]
+	if hero.Width != 800 || hero.Height != 600 || !hero.Lazy || hero.Alt != "Team photo" {
+		t.Errorf("Unexpected hero image: %+v", hero)
+	}
+	if images["/hero-large.jpg"].Descriptor != "1600w" {
+		t.Errorf("Expected srcset candidate with 1600w descriptor, got %+v", images["/hero-large.jpg"])
+	}
+	if !images["/lazy.png"].Lazy {
+		t.Error("Expected data-src image to be lazy loaded")
+	}
+	if source := images["/pic@2x.webp"]; source.Element != "source" || source.Alt != "Picture" {
+		t.Errorf("Expected picture source to share alt text, got %+v", source)
+	}
+	for url := range images {
+		if strings.HasPrefix(url, "data:") {
+			t.Errorf("Data URIs should not be part of the inventory: %s", url)
+		}
+	}
+
+	issues := make(map[string]string)
+	for _, issue := range result.ImageIssues {
+		issues[strings.TrimPrefix(issue.URL, "https://example.com")] = issue.Type
+	}
+
+	expected := map[string]string{
+		"/no-alt.png":    ImageIssueMissingAlt,
+		"/logo.svg":      ImageIssueDecorativeMisuse,
+		"/divider.gif":   ImageIssueDecorativeMisuse,
+		"/photo_123.jpg": ImageIssueFilenameAlt,
+	}
+	for url, issueType := range expected {
+		if issues[url] != issueType {
+			t.Errorf("Expected %s issue for %s, got '%s'", issueType, url, issues[url])
+		}
+	}
+	if _, exists := issues["/hero.jpg"]; exists {
+		t.Error("Did not expect an issue for a described image")
+	}
+}
+
+func TestExtractImagesBoundsStoredText(t *testing.T) {
+	longAlt := strings.Repeat("é", maxImageAltLength+50)
+	longURL := "/" + strings.Repeat("a", maxStoredURLLength) + ".png"
+	sampleHTML := `<html><body>
+    <img src="/photo.jpg" alt="` + longAlt + `">
+    <img src="` + longURL + `" alt="Tracking pixel">
+</body></html>`
+
+	result, err := ParseHTML(strings.NewReader(sampleHTML), "https://example.com/")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	if len(result.Images) != 1 {
+		t.Fatalf("Expected the overlong image URL to be skipped, got %d images", len(result.Images))
+	}
+	alt := result.Images[0].Alt
+	if !utf8.ValidString(alt) || utf8.RuneCountInString(alt) != maxImageAltLength {
+		t.Errorf("Expected the alt text to be cut to %d characters, got %d", maxImageAltLength, utf8.RuneCountInString(alt))
+	}
+}
+
+func TestApplyImageChecks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/large.jpg":
+			w.Header().Set("Content-Length", "2000000")
+			w.WriteHeader(http.StatusOK)
+		case "/small.jpg":
+			w.Header().Set("Content-Length", "1000")
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	images := []ImageInfo{
+		{URL: server.URL + "/large.jpg", Size: -1},
+		{URL: server.URL + "/small.jpg", Size: -1},
+		{URL: server.URL + "/missing.jpg", Size: -1},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	analyzer := NewLinkAnalyzer(nil, nil, nil)
+	issues := applyImageChecks(images, analyzer.CheckLinks(ctx, ImageURLs(images)))
+
+	if images[0].Size != 2000000 || images[1].Size != 1000 {
+		t.Errorf("Expected Content-Length to be recorded, got %d and %d", images[0].Size, images[1].Size)
+	}
+	if !images[2].Broken || images[2].StatusCode != 404 {
+		t.Errorf("Expected missing image to be broken, got %+v", images[2])
+	}
+
+	found := make(map[string]string)
+	for _, issue := range issues {
+		found[issue.URL] = issue.Type
+	}
+	if found[server.URL+"/large.jpg"] != ImageIssueOversized {
+		t.Errorf("Expected large image to be oversized, got issues %+v", issues)
+	}
+	if found[server.URL+"/missing.jpg"] != ImageIssueBroken {
+		t.Errorf("Expected missing image to be broken, got issues %+v", issues)
+	}
+	if _, exists := found[server.URL+"/small.jpg"]; exists {
+		t.Error("Did not expect an issue for a small image")
+	}
+}
@@ -0,0 +1,303 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Indexing issue types reported for canonical, hreflang and robots analysis
+const (
+	IssueCanonicalMultiple     = "canonical_multiple"
+	IssueCanonicalCrossHost    = "canonical_cross_host"
+	IssueCanonicalBroken       = "canonical_broken"
+	IssueHreflangInvalidLang   = "hreflang_invalid_lang"
+	IssueHreflangDuplicate     = "hreflang_duplicate_lang"
+	IssueHreflangMissingSelf   = "hreflang_missing_self"
+	IssueHreflangMissingReturn = "hreflang_missing_return"
+	IssueHreflangUnreachable   = "hreflang_unreachable"
+	IssueNoIndex               = "noindex"
+	IssueNoFollow              = "nofollow"
+)
+
+// maxHreflangChecks limits how many alternate pages are fetched for reciprocity checks
+const maxHreflangChecks = 50
+
+// hreflangPattern matches language codes such as "en", "en-us", "zh-hant-tw" or "x-default"
+var hreflangPattern = regexp.MustCompile(`^(x-default|[a-z]{2,3}(-[a-z]{4})?(-([a-z]{2}|[0-9]{3}))?)$`)
+
+// robotsParameterDirectives are directives that carry a value after a colon
+var robotsParameterDirectives = map[string]bool{
+	"max-snippet":       true,
+	"max-image-preview": true,
+	"max-video-preview": true,
+	"unavailable_after": true,
+}
+
+// RobotsDirectives contains parsed robots meta / X-Robots-Tag directives
+type RobotsDirectives struct {
+	NoIndex    bool
+	NoFollow   bool
+	Directives []string // Normalized directives in the order they were declared
+}
+
+// IndexingIssue describes a problem found during canonical, hreflang or robots analysis
+type IndexingIssue struct {
+	Type    string
+	URL     string
+	Message string
+}
+
+// ParseRobotsDirectives parses a robots meta content or X-Robots-Tag header value
+func ParseRobotsDirectives(value string) RobotsDirectives {
+	var directives RobotsDirectives
+
+	for _, part := range strings.Split(value, ",") {
+		directive := strings.ToLower(strings.TrimSpace(part))
+		if directive == "" {
+			continue
+		}
+
+		// X-Robots-Tag may be scoped to a user agent ("googlebot: noindex")
+		if name, rest, found := strings.Cut(directive, ":"); found && !robotsParameterDirectives[name] {
+			directive = strings.TrimSpace(rest)
+			if directive == "" {
+				continue
+			}
+		}
+
+		directives.Directives = append(directives.Directives, directive)
+		switch directive {
+		case "noindex":
+			directives.NoIndex = true
+		case "nofollow":
+			directives.NoFollow = true
+		case "none":
+			directives.NoIndex = true
+			directives.NoFollow = true
+		}
+	}
+
+	return directives
+}
+
+// Merge combines two sets of directives, the most restrictive value wins
+func (r RobotsDirectives) Merge(other RobotsDirectives) RobotsDirectives {
+	merged := RobotsDirectives{
+		NoIndex:  r.NoIndex || other.NoIndex,
+		NoFollow: r.NoFollow || other.NoFollow,
+	}
+
+	seen := make(map[string]bool)
+	for _, directive := range append(append([]string{}, r.Directives...), other.Directives...) {
+		if !seen[directive] {
+			seen[directive] = true
+			merged.Directives = append(merged.Directives, directive)
+		}
+	}
+
+	return merged
+}
+
+// String returns the directives as a comma-separated list
+func (r RobotsDirectives) String() string {
+	return strings.Join(r.Directives, ", ")
+}
+
+// ParseXRobotsTag parses all X-Robots-Tag header values of a response
+func ParseXRobotsTag(header http.Header) RobotsDirectives {
+	var directives RobotsDirectives
+	for _, value := range header.Values("X-Robots-Tag") {
+		directives = directives.Merge(ParseRobotsDirectives(value))
+	}
+	return directives
+}
+
+// analyzeIndexing checks canonical, hreflang and robots data for a parsed page
+func (c *CrawlerService) analyzeIndexing(ctx context.Context, pageURL string, parseResult *ParseResult, robots RobotsDirectives, linkAnalyzer *LinkAnalyzer) []IndexingIssue {
+	issues := []IndexingIssue{}
+
+	if robots.NoIndex {
+		issues = append(issues, IndexingIssue{
+			Type:    IssueNoIndex,
+			URL:     pageURL,
+			Message: "Page is excluded from search indexes (noindex)",
+		})
+	}
+	if robots.NoFollow {
+		issues = append(issues, IndexingIssue{
+			Type:    IssueNoFollow,
+			URL:     pageURL,
+			Message: "Search engines are told not to follow links on this page (nofollow)",
+		})
+	}
+
+	issues = append(issues, c.checkCanonical(ctx, pageURL, parseResult, linkAnalyzer)...)
+	issues = append(issues, c.checkHreflangs(ctx, pageURL, parseResult.Hreflangs, linkAnalyzer.filter)...)
+
+	return issues
+}
+
+// checkCanonical flags multiple, cross-host and broken canonical URLs
+func (c *CrawlerService) checkCanonical(ctx context.Context, pageURL string, parseResult *ParseResult, linkAnalyzer *LinkAnalyzer) []IndexingIssue {
+	var issues []IndexingIssue
+
+	if parseResult.CanonicalCount > 1 {
+		issues = append(issues, IndexingIssue{
+			Type:    IssueCanonicalMultiple,
+			URL:     parseResult.CanonicalURL,
+			Message: fmt.Sprintf("Page declares %d canonical URLs", parseResult.CanonicalCount),
+		})
+	}
+
+	if parseResult.CanonicalURL == "" {
+		return issues
+	}
+
+	if !sameHost(pageURL, parseResult.CanonicalURL) {
+		issues = append(issues, IndexingIssue{
+			Type:    IssueCanonicalCrossHost,
+			URL:     parseResult.CanonicalURL,
+			Message: "Canonical URL points to a different host",
+		})
+	}
+
+	// A canonical pointing at the page itself was already fetched successfully, and
+	// excluded URLs are not requested at all
+	if normalizeComparableURL(parseResult.CanonicalURL) == normalizeComparableURL(pageURL) || linkAnalyzer.filter.Excludes(parseResult.CanonicalURL) {
+		return issues
+	}
+
+	if brokenInfo := linkAnalyzer.checkLink(ctx, parseResult.CanonicalURL); brokenInfo != nil {
+		issues = append(issues, IndexingIssue{
+			Type:    IssueCanonicalBroken,
+			URL:     parseResult.CanonicalURL,
+			Message: fmt.Sprintf("Canonical URL is not reachable: %s", brokenInfo.Error),
+		})
+	}
+
+	return issues
+}
+
+// checkHreflangs validates language codes and verifies that alternates not excluded by the
+// filter link back to the page
+func (c *CrawlerService) checkHreflangs(ctx context.Context, pageURL string, hreflangs []HreflangLink, filter *LinkFilter) []IndexingIssue {
+	var issues []IndexingIssue
+	if len(hreflangs) == 0 {
+		return issues
+	}
+
+	normalizedPage := normalizeComparableURL(pageURL)
+	seenLangs := make(map[string]string)
+	hasSelf := false
+
+	for _, link := range hreflangs {
+		if !hreflangPattern.MatchString(link.Lang) {
+			issues = append(issues, IndexingIssue{
+				Type:    IssueHreflangInvalidLang,
+				URL:     link.URL,
+				Message: fmt.Sprintf("Invalid hreflang value %q", link.Lang),
+			})
+		}
+
+		if previous, exists := seenLangs[link.Lang]; exists && previous != link.URL {
+			issues = append(issues, IndexingIssue{
+				Type:    IssueHreflangDuplicate,
+				URL:     link.URL,
+				Message: fmt.Sprintf("hreflang %q is declared for more than one URL", link.Lang),
+			})
+		}
+		seenLangs[link.Lang] = link.URL
+
+		if normalizeComparableURL(link.URL) == normalizedPage {
+			hasSelf = true
+		}
+	}
+
+	if !hasSelf {
+		issues = append(issues, IndexingIssue{
+			Type:    IssueHreflangMissingSelf,
+			URL:     pageURL,
+			Message: "hreflang set does not include a self-referencing entry",
+		})
+	}
+
+	// Verify reciprocal return links on each alternate page
+	checked := make(map[string]bool)
+	for _, link := range hreflangs {
+		normalized := normalizeComparableURL(link.URL)
+		if normalized == normalizedPage || checked[normalized] || filter.Excludes(link.URL) {
+			continue
+		}
+		if len(checked) >= maxHreflangChecks || ctx.Err() != nil {
+			break
+		}
+		checked[normalized] = true
+
+		alternates, err := c.fetchHreflangs(ctx, link.URL)
+		if err != nil {
+			issues = append(issues, IndexingIssue{
+				Type:    IssueHreflangUnreachable,
+				URL:     link.URL,
+				Message: fmt.Sprintf("Alternate page could not be checked: %v", err),
+			})
+			continue
+		}
+
+		linksBack := false
+		for _, alternate := range alternates {
+			if normalizeComparableURL(alternate.URL) == normalizedPage {
+				linksBack = true
+				break
+			}
+		}
+		if !linksBack {
+			issues = append(issues, IndexingIssue{
+				Type:    IssueHreflangMissingReturn,
+				URL:     link.URL,
+				Message: fmt.Sprintf("Alternate page (%s) does not link back with hreflang", link.Lang),
+			})
+		}
+	}
+
+	return issues
+}
+
+// fetchHreflangs downloads a page and returns its hreflang links
+func (c *CrawlerService) fetchHreflangs(ctx context.Context, pageURL string) ([]HreflangLink, error) {
+	parseResult, err := c.fetchAndParse(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+	return parseResult.Hreflangs, nil
+}
+
+// sameHost reports whether two URLs share the same host name
+func sameHost(a, b string) bool {
+	parsedA, errA := url.Parse(a)
+	parsedB, errB := url.Parse(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return strings.EqualFold(parsedA.Hostname(), parsedB.Hostname())
+}
+
+// normalizeComparableURL normalizes a URL so equivalent forms compare equal
+func normalizeComparableURL(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	parsedURL.Fragment = ""
+	parsedURL.Scheme = strings.ToLower(parsedURL.Scheme)
+	parsedURL.Host = strings.ToLower(parsedURL.Host)
+	if parsedURL.Path == "" {
+		parsedURL.Path = "/"
+	}
+
+	return parsedURL.String()
+}
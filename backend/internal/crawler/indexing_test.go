@@ -0,0 +1,169 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRobotsDirectives(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        string
+		wantNoIndex  bool
+		wantNoFollow bool
+	}{
+		{name: "empty", value: "", wantNoIndex: false, wantNoFollow: false},
+		{name: "index follow", value: "index, follow", wantNoIndex: false, wantNoFollow: false},
+		{name: "noindex", value: "NOINDEX, follow", wantNoIndex: true, wantNoFollow: false},
+		{name: "none", value: "none", wantNoIndex: true, wantNoFollow: true},
+		{name: "user agent scoped", value: "googlebot: nofollow", wantNoIndex: false, wantNoFollow: true},
+		{name: "parameter directive", value: "max-snippet:50, noindex", wantNoIndex: true, wantNoFollow: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			directives := ParseRobotsDirectives(tt.value)
+			if directives.NoIndex != tt.wantNoIndex {
+				t.Errorf("NoIndex = %v, want %v", directives.NoIndex, tt.wantNoIndex)
+			}
+			if directives.NoFollow != tt.wantNoFollow {
+				t.Errorf("NoFollow = %v, want %v", directives.NoFollow, tt.wantNoFollow)
+			}
+		})
+	}
+
+	if got := ParseRobotsDirectives("max-snippet:50").String(); got != "max-snippet:50" {
+		t.Errorf("Expected parameter directive to be kept, got '%s'", got)
+	}
+}
+
+func TestCheckHreflangs(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/de":
+			// Links back to the English page
+			fmt.Fprintf(w, `<html><head>
+				<link rel="alternate" hreflang="en" href="%s/en">
+				<link rel="alternate" hreflang="de" href="%s/de">
+			</head></html>`, server.URL, server.URL)
+		case "/fr":
+			// Missing the return link
+			fmt.Fprintf(w, `<html><head>
+				<link rel="alternate" hreflang="fr" href="%s/fr">
+			</head></html>`, server.URL)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	crawler := NewCrawlerService(nil)
+	hreflangs := []HreflangLink{
+		{Lang: "en", URL: server.URL + "/en"},
+		{Lang: "de", URL: server.URL + "/de"},
+		{Lang: "fr", URL: server.URL + "/fr"},
+		{Lang: "english", URL: server.URL + "/gone"},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	issues := crawler.checkHreflangs(ctx, server.URL+"/en", hreflangs, DefaultLinkFilter())
+
+	found := make(map[string]string)
+	for _, issue := range issues {
+		found[issue.Type] = issue.URL
+	}
+
+	if found[IssueHreflangMissingReturn] != server.URL+"/fr" {
+		t.Errorf("Expected missing return link for /fr, got issues %+v", issues)
+	}
+	if found[IssueHreflangInvalidLang] != server.URL+"/gone" {
+		t.Errorf("Expected invalid language for 'english', got issues %+v", issues)
+	}
+	if found[IssueHreflangUnreachable] != server.URL+"/gone" {
+		t.Errorf("Expected unreachable alternate for /gone, got issues %+v", issues)
+	}
+	if _, exists := found[IssueHreflangMissingSelf]; exists {
+		t.Error("Did not expect missing self-reference issue")
+	}
+	for _, issue := range issues {
+		if issue.Type == IssueHreflangMissingReturn && strings.HasSuffix(issue.URL, "/de") {
+			t.Error("Did not expect missing return link for /de")
+		}
+	}
+}
+
+func TestCheckHreflangsSkipsExcludedAlternates(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	filter, err := NewLinkFilter([]LinkFilterRule{{Action: FilterExclude, PathPattern: "/private/**"}})
+	if err != nil {
+		t.Fatalf("Failed to build filter: %v", err)
+	}
+	hreflangs := []HreflangLink{
+		{Lang: "en", URL: server.URL + "/en"},
+		{Lang: "de", URL: server.URL + "/private/de"},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	issues := NewCrawlerService(nil).checkHreflangs(ctx, server.URL+"/en", hreflangs, filter)
+	if requests != 0 || len(issues) != 0 {
+		t.Errorf("Expected the excluded alternate not to be fetched, got %d requests and issues %+v", requests, issues)
+	}
+}
+
+func TestIndexingAfterRedirect(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/page", http.StatusMovedPermanently)
+		case "/page":
+			w.Header().Set("Content-Type", "text/html")
+			w.Header().Set("X-Robots-Tag", "noindex")
+			fmt.Fprintf(w, `<html><head>
+				<link rel="canonical" href="%s/page">
+				<link rel="alternate" hreflang="en" href="%s/page">
+			</head></html>`, server.URL, server.URL)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	crawler := NewCrawlerService(nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result := crawler.crawlURL(ctx, server.URL+"/old", nil)
+	if result.Error != "" {
+		t.Fatalf("Crawl failed: %s", result.Error)
+	}
+
+	// The page reached through the redirect references itself
+	for _, issue := range result.IndexingIssues {
+		switch issue.Type {
+		case IssueHreflangMissingSelf, IssueHreflangMissingReturn, IssueCanonicalBroken:
+			t.Errorf("Did not expect %s for the redirected page: %+v", issue.Type, issue)
+		}
+	}
+	if !result.ParseResult.XRobotsTag.NoIndex {
+		t.Error("Expected the X-Robots-Tag header on the parse result")
+	}
+}
@@ -0,0 +1,108 @@
+package crawler
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LinkCache is a size-bounded, in-memory cache of link check outcomes shared by all crawls.
+// Successes and failures expire after different TTLs; the least recently used entry is
+// evicted when the cache is full.
+type LinkCache struct {
+	mutex      sync.Mutex
+	capacity   int
+	successTTL time.Duration
+	failureTTL time.Duration
+	entries    map[string]*list.Element
+	order      *list.List // Front is the most recently used entry
+	now        func() time.Time
+}
+
+type linkCacheEntry struct {
+	url       string
+	result    LinkCheckResult
+	expiresAt time.Time
+}
+
+// NewLinkCache creates a link check cache; a capacity of 0 disables caching
+func NewLinkCache(capacity int, successTTL, failureTTL time.Duration) *LinkCache {
+	return &LinkCache{
+		capacity:   capacity,
+		successTTL: successTTL,
+		failureTTL: failureTTL,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+// Get returns a copy of the cached outcome for a URL if it hasn't expired
+func (lc *LinkCache) Get(url string) (*LinkCheckResult, bool) {
+	if lc == nil {
+		return nil, false
+	}
+
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	element, exists := lc.entries[url]
+	if !exists {
+		return nil, false
+	}
+
+	entry := element.Value.(*linkCacheEntry)
+	if lc.now().After(entry.expiresAt) {
+		lc.order.Remove(element)
+		delete(lc.entries, url)
+		return nil, false
+	}
+
+	lc.order.MoveToFront(element)
+	result := entry.result
+	return &result, true
+}
+
+// Put stores the outcome of a link check
+func (lc *LinkCache) Put(url string, result *LinkCheckResult) {
+	if lc == nil || lc.capacity <= 0 || result == nil {
+		return
+	}
+
+	ttl := lc.successTTL
+	if result.IsBroken() {
+		ttl = lc.failureTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	entry := &linkCacheEntry{url: url, result: *result, expiresAt: lc.now().Add(ttl)}
+	if element, exists := lc.entries[url]; exists {
+		element.Value = entry
+		lc.order.MoveToFront(element)
+		return
+	}
+
+	lc.entries[url] = lc.order.PushFront(entry)
+
+	for lc.order.Len() > lc.capacity {
+		oldest := lc.order.Back()
+		lc.order.Remove(oldest)
+		delete(lc.entries, oldest.Value.(*linkCacheEntry).url)
+	}
+}
+
+// Len returns the number of cached outcomes, including expired ones not yet evicted
+func (lc *LinkCache) Len() int {
+	if lc == nil {
+		return 0
+	}
+
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+	return lc.order.Len()
+}
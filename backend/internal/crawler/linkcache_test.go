@@ -0,0 +1,85 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLinkCacheTTL(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewLinkCache(10, time.Hour, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	cache.Put("https://example.com/ok", &LinkCheckResult{StatusCode: 200, Class: LinkClassOK})
+	cache.Put("https://example.com/missing", &LinkCheckResult{StatusCode: 404, Class: LinkClassBroken, Error: "HTTP 404"})
+
+	now = now.Add(2 * time.Minute)
+
+	if _, exists := cache.Get("https://example.com/missing"); exists {
+		t.Error("Expected failed outcome to expire after the failure TTL")
+	}
+	if cached, exists := cache.Get("https://example.com/ok"); !exists || cached.StatusCode != 200 {
+		t.Error("Expected successful outcome to still be cached")
+	}
+
+	now = now.Add(time.Hour)
+	if _, exists := cache.Get("https://example.com/ok"); exists {
+		t.Error("Expected successful outcome to expire after the success TTL")
+	}
+}
+
+func TestLinkCacheEviction(t *testing.T) {
+	cache := NewLinkCache(2, time.Hour, time.Hour)
+
+	cache.Put("a", &LinkCheckResult{Class: LinkClassOK})
+	cache.Put("b", &LinkCheckResult{Class: LinkClassOK})
+	cache.Get("a") // a is now the most recently used
+	cache.Put("c", &LinkCheckResult{Class: LinkClassOK})
+
+	if cache.Len() != 2 {
+		t.Errorf("Expected cache to hold 2 entries, got %d", cache.Len())
+	}
+	if _, exists := cache.Get("b"); exists {
+		t.Error("Expected least recently used entry to be evicted")
+	}
+	if _, exists := cache.Get("a"); !exists {
+		t.Error("Expected recently used entry to be kept")
+	}
+}
+
+func TestCheckLinksUsesCache(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cache := NewLinkCache(10, time.Hour, time.Minute)
+	links := []string{server.URL + "/page"}
+
+	for i := 0; i < 2; i++ {
+		analyzer := NewLinkAnalyzer(nil, nil, nil)
+		analyzer.UseCache(cache, false)
+		if checks := analyzer.CheckLinks(ctx, links); checks[links[0]] == nil || checks[links[0]].IsBroken() {
+			t.Fatalf("Expected %s to be checked successfully", links[0])
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected the second analysis to use the cache, got %d requests", requests)
+	}
+
+	analyzer := NewLinkAnalyzer(nil, nil, nil)
+	analyzer.UseCache(cache, true)
+	analyzer.CheckLinks(ctx, links)
+	if requests != 2 {
+		t.Errorf("Expected a fresh check to bypass the cache, got %d requests", requests)
+	}
+}
@@ -0,0 +1,173 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Link check outcome classes
+const (
+	LinkClassOK          = "ok"
+	LinkClassBroken      = "broken"
+	LinkClassBlocked     = "blocked"      // Bot protection or challenge page
+	LinkClassRateLimited = "rate_limited" // 429, or 503 with Retry-After
+	LinkClassTimeout     = "timeout"
+	LinkClassDNSFailure  = "dns_failure"
+	LinkClassUnreachable = "host_unreachable" // Skipped because the host's circuit breaker is open
+)
+
+// LinkClasses lists every class a link check can end in
+var LinkClasses = []string{
+	LinkClassOK, LinkClassBroken, LinkClassBlocked, LinkClassRateLimited, LinkClassTimeout, LinkClassDNSFailure,
+	LinkClassUnreachable,
+}
+
+// maxRetryAfter is the longest Retry-After delay the link checker waits for
+const maxRetryAfter = 30 * time.Second
+
+// maxChallengeBodyBytes limits how much of an error response is read to spot challenge pages
+const maxChallengeBodyBytes = 16 * 1024
+
+// challengeMarkers are texts found on bot protection and challenge pages
+var challengeMarkers = []string{
+	"just a moment...", "attention required! | cloudflare", "cf-browser-verification", "challenge-platform",
+	"captcha", "_incapsula_resource", "px-captcha", "ddos-guard", "request unsuccessful. incapsula",
+}
+
+// DefaultCountedClasses returns the classes that count toward BrokenLinks by default
+func DefaultCountedClasses() []string {
+	return []string{LinkClassBroken, LinkClassTimeout, LinkClassDNSFailure, LinkClassUnreachable}
+}
+
+// IsValidLinkClass checks whether a class name is known
+func IsValidLinkClass(class string) bool {
+	for _, known := range LinkClasses {
+		if class == known {
+			return true
+		}
+	}
+	return false
+}
+
+// CountBrokenLinks counts the failed links whose class is counted; nil counted uses the defaults
+func CountBrokenLinks(links []BrokenLinkInfo, counted []string) int {
+	if counted == nil {
+		counted = DefaultCountedClasses()
+	}
+
+	countedSet := make(map[string]bool)
+	for _, class := range counted {
+		countedSet[class] = true
+	}
+
+	count := 0
+	for _, link := range links {
+		class := link.Class
+		if class == "" {
+			class = LinkClassBroken
+		}
+		if countedSet[class] {
+			count++
+		}
+	}
+	return count
+}
+
+// classifyResponse classifies an error response, reading a part of the body for challenge pages
+func classifyResponse(resp *http.Response) string {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return LinkClassRateLimited
+	case resp.StatusCode == 999: // Non-standard status used by some sites to reject crawlers
+		return LinkClassBlocked
+	case resp.StatusCode == http.StatusServiceUnavailable && resp.Header.Get("Retry-After") != "":
+		return LinkClassRateLimited
+	}
+
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusServiceUnavailable {
+		if isChallengeResponse(resp) {
+			return LinkClassBlocked
+		}
+	}
+
+	return LinkClassBroken
+}
+
+// isChallengeResponse detects bot protection from headers or the response body
+func isChallengeResponse(resp *http.Response) bool {
+	if resp.Header.Get("cf-mitigated") != "" {
+		return true
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxChallengeBodyBytes))
+	bodyLower := strings.ToLower(string(body))
+	for _, marker := range challengeMarkers {
+		if strings.Contains(bodyLower, marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// classifyError classifies a failed request by its network error
+func classifyError(err error) string {
+	if err == nil {
+		return LinkClassBroken
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return LinkClassDNSFailure
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return LinkClassTimeout
+	}
+
+	return LinkClassBroken
+}
+
+// describeFailure builds the error message for an error response
+func describeFailure(statusCode int, class string) string {
+	switch class {
+	case LinkClassBlocked:
+		return fmt.Sprintf("HTTP %d (blocked by bot protection)", statusCode)
+	case LinkClassRateLimited:
+		return fmt.Sprintf("HTTP %d (rate limited)", statusCode)
+	}
+	return fmt.Sprintf("HTTP %d", statusCode)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,113 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLinkCheckClasses(t *testing.T) {
+	var throttled int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("<html><body>You don't have permission to access this page.</body></html>"))
+		case "/challenge":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("<html><head><title>Just a moment...</title></head></html>"))
+		case "/throttled-once":
+			// Every other request is throttled with a short Retry-After
+			if atomic.AddInt32(&throttled, 1)%2 == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case "/throttled":
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/slow":
+			time.Sleep(500 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	analyzer := NewLinkAnalyzer(nil, nil, nil)
+	analyzer.client.Timeout = 200 * time.Millisecond
+
+	tests := []struct {
+		path  string
+		class string
+	}{
+		{"/ok", LinkClassOK},
+		{"/missing", LinkClassBroken},
+		{"/forbidden", LinkClassBroken},
+		{"/challenge", LinkClassBlocked},
+		{"/throttled-once", LinkClassOK},
+		{"/slow", LinkClassTimeout},
+		{"/throttled", LinkClassRateLimited}, // Pauses the host, so it runs last
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	for _, tt := range tests {
+		check := analyzer.inspectLink(ctx, server.URL+tt.path)
+		if check.Class != tt.class {
+			t.Errorf("%s: expected class %s, got %s (%s)", tt.path, tt.class, check.Class, check.Error)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		delay time.Duration
+		ok    bool
+	}{
+		{"120", 120 * time.Second, true},
+		{"Mon, 01 Jan 2024 12:00:30 GMT", 30 * time.Second, true},
+		{"Mon, 01 Jan 2024 11:00:00 GMT", 0, true},
+		{"", 0, false},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		delay, ok := parseRetryAfter(tt.value, now)
+		if ok != tt.ok || delay != tt.delay {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, delay, ok, tt.delay, tt.ok)
+		}
+	}
+}
+
+func TestCountBrokenLinks(t *testing.T) {
+	links := []BrokenLinkInfo{
+		{URL: "a", Class: LinkClassBroken},
+		{URL: "b", Class: LinkClassBlocked},
+		{URL: "c", Class: LinkClassRateLimited},
+		{URL: "d", Class: LinkClassTimeout},
+		{URL: "e", Class: LinkClassDNSFailure},
+	}
+
+	if count := CountBrokenLinks(links, nil); count != 3 {
+		t.Errorf("Expected 3 links counted by default, got %d", count)
+	}
+	if count := CountBrokenLinks(links, []string{LinkClassBroken, LinkClassBlocked}); count != 2 {
+		t.Errorf("Expected 2 links counted, got %d", count)
+	}
+	if count := CountBrokenLinks(links, []string{}); count != 0 {
+		t.Errorf("Expected no links counted, got %d", count)
+	}
+}
@@ -0,0 +1,242 @@
+package crawler
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Link filter rule actions
+const (
+	FilterInclude = "include"
+	FilterExclude = "exclude"
+)
+
+// Link filter path pattern types
+const (
+	PatternGlob  = "glob"
+	PatternRegex = "regex"
+)
+
+// LinkFilterRule decides whether matching links are checked.
+// An empty host or path pattern matches every link.
+type LinkFilterRule struct {
+	Action      string // FilterInclude or FilterExclude
+	HostSuffix  string // Matches the host and all of its subdomains
+	PathPattern string
+	PatternType string // PatternGlob or PatternRegex
+	Reason      string // Reported for links skipped by this rule
+}
+
+// SkippedLink is a link that was not checked because of a filter rule
+type SkippedLink struct {
+	URL    string
+	Reason string
+}
+
+// LinkFilter applies an ordered list of rules; the first matching rule wins
+// and links without a matching rule are checked
+type LinkFilter struct {
+	rules []compiledFilterRule
+}
+
+type compiledFilterRule struct {
+	LinkFilterRule
+	path *regexp.Regexp
+}
+
+// defaultSkipHosts are services that are commonly reliable or block link checkers
+var defaultSkipHosts = []struct {
+	category string
+	hosts    []string
+}{
+	{"Social media platform", []string{
+		"facebook.com", "twitter.com", "instagram.com", "linkedin.com", "youtube.com",
+		"tiktok.com", "pinterest.com", "snapchat.com", "whatsapp.com",
+	}},
+	{"Common CDN", []string{
+		"googleapis.com", "cloudflare.com", "jsdelivr.net", "unpkg.com",
+		"cdnjs.cloudflare.com", "maxcdn.bootstrapcdn.com",
+	}},
+	{"Major tech company", []string{
+		"microsoft.com", "apple.com", "amazon.com", "google.com",
+	}},
+	{"Development platform", []string{
+		"github.com", "gitlab.com", "bitbucket.com",
+	}},
+}
+
+// DefaultLinkFilterRules returns the built-in exclude rules, applied after user rules
+func DefaultLinkFilterRules() []LinkFilterRule {
+	var rules []LinkFilterRule
+	for _, group := range defaultSkipHosts {
+		for _, host := range group.hosts {
+			rules = append(rules, LinkFilterRule{
+				Action:     FilterExclude,
+				HostSuffix: host,
+				Reason:     fmt.Sprintf("%s (%s) skipped by default", group.category, host),
+			})
+		}
+	}
+	return rules
+}
+
+// DefaultLinkFilter returns a filter with only the built-in rules
+func DefaultLinkFilter() *LinkFilter {
+	filter, _ := NewLinkFilter(DefaultLinkFilterRules())
+	return filter
+}
+
+// NewLinkFilter validates and compiles filter rules
+func NewLinkFilter(rules []LinkFilterRule) (*LinkFilter, error) {
+	filter := &LinkFilter{}
+
+	for _, rule := range rules {
+		compiled, err := compileFilterRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		filter.rules = append(filter.rules, compiled)
+	}
+
+	return filter, nil
+}
+
+// ValidateLinkFilterRule checks that a rule has a known action and a valid pattern
+func ValidateLinkFilterRule(rule LinkFilterRule) error {
+	_, err := compileFilterRule(rule)
+	return err
+}
+
+// compileFilterRule normalizes a rule and compiles its path pattern
+func compileFilterRule(rule LinkFilterRule) (compiledFilterRule, error) {
+	compiled := compiledFilterRule{LinkFilterRule: rule}
+
+	if rule.Action != FilterInclude && rule.Action != FilterExclude {
+		return compiled, fmt.Errorf("invalid filter action: %q", rule.Action)
+	}
+
+	compiled.HostSuffix = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(rule.HostSuffix)), ".")
+
+	if rule.PathPattern == "" {
+		return compiled, nil
+	}
+
+	var expression string
+	switch rule.PatternType {
+	case PatternGlob, "":
+		compiled.PatternType = PatternGlob
+		expression = globToRegexp(rule.PathPattern)
+	case PatternRegex:
+		expression = rule.PathPattern
+	default:
+		return compiled, fmt.Errorf("invalid pattern type: %q", rule.PatternType)
+	}
+
+	pattern, err := regexp.Compile(expression)
+	if err != nil {
+		return compiled, fmt.Errorf("invalid path pattern %q: %w", rule.PathPattern, err)
+	}
+	compiled.path = pattern
+
+	return compiled, nil
+}
+
+// Apply splits links into the ones to check and the ones skipped, with the reason
+func (f *LinkFilter) Apply(links []string) ([]string, []SkippedLink) {
+	var kept []string
+	var skipped []SkippedLink
+
+	for _, link := range links {
+		if rule := f.match(link); rule != nil && rule.Action == FilterExclude {
+			reason := rule.Reason
+			if reason == "" {
+				reason = rule.describe()
+			}
+			skipped = append(skipped, SkippedLink{URL: link, Reason: reason})
+			continue
+		}
+		kept = append(kept, link)
+	}
+
+	return kept, skipped
+}
+
+// Excludes reports whether a link is skipped by the filter rules. Pages fetched for other
+// checks, such as fragment and hreflang targets, must pass the same rules.
+func (f *LinkFilter) Excludes(link string) bool {
+	rule := f.match(link)
+	return rule != nil && rule.Action == FilterExclude
+}
+
+// match returns the first rule matching a link, nil if none matches
+func (f *LinkFilter) match(link string) *compiledFilterRule {
+	if f == nil {
+		return nil
+	}
+
+	parsedURL, err := url.Parse(link)
+	if err != nil {
+		return nil
+	}
+	host := strings.ToLower(parsedURL.Hostname())
+	path := parsedURL.Path
+	if path == "" {
+		path = "/"
+	}
+
+	for i := range f.rules {
+		rule := &f.rules[i]
+		if rule.HostSuffix != "" && host != rule.HostSuffix && !strings.HasSuffix(host, "."+rule.HostSuffix) {
+			continue
+		}
+		if rule.path != nil && !rule.path.MatchString(path) {
+			continue
+		}
+		return rule
+	}
+
+	return nil
+}
+
+// describe builds a reason for rules that don't define one
+func (r *compiledFilterRule) describe() string {
+	var parts []string
+	if r.HostSuffix != "" {
+		parts = append(parts, "host "+r.HostSuffix)
+	}
+	if r.PathPattern != "" {
+		parts = append(parts, fmt.Sprintf("path %s %q", r.PatternType, r.PathPattern))
+	}
+	if len(parts) == 0 {
+		return "Excluded by filter rule"
+	}
+	return "Excluded by filter rule for " + strings.Join(parts, ", ")
+}
+
+// globToRegexp converts a path glob to an anchored regular expression.
+// "*" matches within a path segment, "**" across segments and "?" a single character.
+func globToRegexp(glob string) string {
+	var expression strings.Builder
+	expression.WriteString("^")
+
+	for i := 0; i < len(glob); i++ {
+		switch glob[i] {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				expression.WriteString(".*")
+				i++
+			} else {
+				expression.WriteString("[^/]*")
+			}
+		case '?':
+			expression.WriteString("[^/]")
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(glob[i])))
+		}
+	}
+
+	expression.WriteString("$")
+	return expression.String()
+}
@@ -0,0 +1,81 @@
+package crawler
+
+import (
+	"testing"
+)
+
+func TestLinkFilterHostSuffix(t *testing.T) {
+	filter := DefaultLinkFilter()
+
+	links := []string{
+		"https://github.com/user/repo",
+		"https://gist.github.com/user/1",
+		"https://notgithub.com.example/page",
+		"https://example.com/github.com",
+	}
+
+	kept, skipped := filter.Apply(links)
+
+	if len(skipped) != 2 {
+		t.Fatalf("Expected 2 skipped links, got %+v", skipped)
+	}
+	for _, link := range skipped {
+		if link.Reason == "" {
+			t.Errorf("Expected a reason for skipped link %s", link.URL)
+		}
+	}
+
+	if len(kept) != 2 || kept[0] != "https://notgithub.com.example/page" || kept[1] != "https://example.com/github.com" {
+		t.Errorf("Unexpected kept links: %v", kept)
+	}
+}
+
+func TestLinkFilterRules(t *testing.T) {
+	rules := []LinkFilterRule{
+		{Action: FilterInclude, HostSuffix: "github.com", PathPattern: "/myorg/**"},
+		{Action: FilterExclude, PathPattern: "/admin/*", Reason: "Admin pages"},
+		{Action: FilterExclude, HostSuffix: "example.com", PathPattern: `^/tmp/\d+$`, PatternType: PatternRegex},
+	}
+	filter, err := NewLinkFilter(append(rules, DefaultLinkFilterRules()...))
+	if err != nil {
+		t.Fatalf("Failed to build filter: %v", err)
+	}
+
+	tests := []struct {
+		url     string
+		skipped bool
+		reason  string
+	}{
+		{"https://github.com/myorg/project/issues", false, ""},
+		{"https://github.com/other/project", true, ""},
+		{"https://site.com/admin/users", true, "Admin pages"},
+		{"https://site.com/admin/users/1", false, ""},
+		{"https://www.example.com/tmp/42", true, ""},
+		{"https://www.example.com/tmp/abc", false, ""},
+	}
+
+	for _, tt := range tests {
+		_, skipped := filter.Apply([]string{tt.url})
+		if (len(skipped) == 1) != tt.skipped {
+			t.Errorf("%s: expected skipped=%v, got %+v", tt.url, tt.skipped, skipped)
+			continue
+		}
+		if tt.reason != "" && skipped[0].Reason != tt.reason {
+			t.Errorf("%s: expected reason %q, got %q", tt.url, tt.reason, skipped[0].Reason)
+		}
+	}
+}
+
+func TestLinkFilterValidation(t *testing.T) {
+	invalid := []LinkFilterRule{
+		{Action: "ignore"},
+		{Action: FilterExclude, PathPattern: "([", PatternType: PatternRegex},
+		{Action: FilterExclude, PathPattern: "/a", PatternType: "prefix"},
+	}
+
+	for _, rule := range invalid {
+		if err := ValidateLinkFilterRule(rule); err == nil {
+			t.Errorf("Expected rule %+v to be invalid", rule)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package crawler
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// maxLinkEdges limits how many link elements of a single page are recorded
+const maxLinkEdges = 5000
+
+// Limits on the stored text of a link
+const (
+	maxAnchorTextLength = 500 // Anchor and title text
+	maxRelLength        = 255
+)
+
+// Link issue types
+const (
+	LinkIssueGenericAnchor = "generic_anchor"
+	LinkIssueEmptyAnchor   = "empty_anchor"
+	LinkIssueUnsafeBlank   = "blank_without_noopener"
+)
+
+// genericAnchorTexts are anchor texts that say nothing about the link target
+var genericAnchorTexts = map[string]bool{
+	"click here": true, "click": true, "here": true, "this": true, "this link": true, "link": true,
+	"read more": true, "more": true, "learn more": true, "more info": true, "more information": true,
+	"details": true, "continue": true, "continue reading": true, "go": true, "see more": true,
+}
+
+// LinkEdge is a link element from the analyzed page to a target URL
+type LinkEdge struct {
+	URL           string // Resolved target without the fragment
+	AnchorText    string
+	Title         string
+	Rel           string // Lowercase rel values separated by spaces
+	NoFollow      bool
+	Sponsored     bool
+	UGC           bool
+	Internal      bool
+	Image         bool // The link contains an image
+	BlankNoOpener bool // target="_blank" without rel="noopener" or "noreferrer"
+}
+
+// LinkIssue describes an SEO or accessibility problem with a link
+type LinkIssue struct {
+	Type       string
+	URL        string
+	AnchorText string
+	Message    string
+}
+
+// extractLinkEdges records every link element of the page with its anchor text and
+// attributes, and reports generic and empty anchors and unsafe target="_blank" links
+func extractLinkEdges(doc *goquery.Document, baseURL *url.URL) ([]LinkEdge, []LinkIssue) {
+	edges := []LinkEdge{}
+	issues := []LinkIssue{}
+
+	doc.Find("a[href]").Each(func(i int, s *goquery.Selection) {
+		if len(edges) >= maxLinkEdges {
+			return
+		}
+
+		href, _ := s.Attr("href")
+		target, resolvedURL, ok := resolveLinkHref(baseURL, href)
+		if !ok || len(target) > maxStoredURLLength {
+			return
+		}
+
+		text := truncateRunes(anchorText(s), maxAnchorTextLength)
+		title := truncateRunes(SanitizeText(s.AttrOr("title", "")), maxAnchorTextLength)
+
+		edge := LinkEdge{
+			URL:        target,
+			AnchorText: text,
+			Title:      title,
+			Rel:        truncateRunes(strings.Join(strings.Fields(strings.ToLower(s.AttrOr("rel", ""))), " "), maxRelLength),
+			NoFollow:   hasRelValue(s, "nofollow"),
+			Sponsored:  hasRelValue(s, "sponsored"),
+			UGC:        hasRelValue(s, "ugc"),
+			Internal:   resolvedURL.Host == baseURL.Host,
+			Image:      s.Find("img").Length() > 0,
+		}
+		if strings.EqualFold(strings.TrimSpace(s.AttrOr("target", "")), "_blank") {
+			// noreferrer implies noopener
+			edge.BlankNoOpener = !hasRelValue(s, "noopener") && !hasRelValue(s, "noreferrer")
+		}
+		edges = append(edges, edge)
+
+		switch {
+		case text == "" && edge.Title == "" && SanitizeText(s.AttrOr("aria-label", "")) == "":
+			issues = append(issues, LinkIssue{
+				Type:    LinkIssueEmptyAnchor,
+				URL:     target,
+				Message: "Link has no text, image alt text, title or aria-label",
+			})
+		case isGenericAnchorText(text):
+			issues = append(issues, LinkIssue{
+				Type:       LinkIssueGenericAnchor,
+				URL:        target,
+				AnchorText: text,
+				Message:    fmt.Sprintf("Anchor text %q does not describe the link target", text),
+			})
+		}
+
+		if edge.BlankNoOpener && !edge.Internal {
+			issues = append(issues, LinkIssue{
+				Type:       LinkIssueUnsafeBlank,
+				URL:        target,
+				AnchorText: text,
+				Message:    `External link opens in a new tab without rel="noopener"`,
+			})
+		}
+	})
+
+	return edges, issues
+}
+
+// isGenericAnchorText checks whether an anchor text is a generic phrase such as "click here"
+func isGenericAnchorText(text string) bool {
+	text = strings.ToLower(strings.Trim(text, " .:!?»›→…>"))
+	return genericAnchorTexts[text]
+}
+
+// resolveLinkHref resolves a link href and cleans it for deduplication. It reports false
+// for empty hrefs, same-page fragments and non-navigational schemes such as mailto:.
+func resolveLinkHref(baseURL *url.URL, href string) (string, *url.URL, bool) {
+	// Clean and normalize the URL
+	href = strings.TrimSpace(href)
+	if href == "" {
+		return "", nil, false
+	}
+
+	// Skip javascript:, mailto:, tel:, etc.
+	if strings.HasPrefix(href, "javascript:") ||
+		strings.HasPrefix(href, "mailto:") ||
+		strings.HasPrefix(href, "tel:") ||
+		strings.HasPrefix(href, "#") {
+		return "", nil, false
+	}
+
+	// Parse the link URL
+	linkURL, err := url.Parse(href)
+	if err != nil {
+		return "", nil, false
+	}
+
+	// Resolve relative URLs
+	resolvedURL := baseURL.ResolveReference(linkURL)
+
+	// Remove fragment for deduplication
+	cleanURL := resolvedURL.Scheme + "://" + resolvedURL.Host + resolvedURL.Path
+	if resolvedURL.RawQuery != "" {
+		cleanURL += "?" + resolvedURL.RawQuery
+	}
+
+	return cleanURL, resolvedURL, true
+}
+
+// anchorText returns the visible text of a link, falling back to the alt text of its images
+func anchorText(s *goquery.Selection) string {
+	text := SanitizeText(s.Text())
+	if text != "" {
+		return text
+	}
+
+	var alts []string
+	s.Find("img[alt]").Each(func(i int, img *goquery.Selection) {
+		if alt := SanitizeText(img.AttrOr("alt", "")); alt != "" {
+			alts = append(alts, alt)
+		}
+	})
+	return strings.Join(alts, " ")
+}
+
+// LinkTargetVariants returns the forms a page URL can take as a link target, so that
+// "https://example.com" also matches links to "https://example.com/"
+func LinkTargetVariants(pageURL string) []string {
+	parsedURL, err := url.Parse(strings.TrimSpace(pageURL))
+	if err != nil || parsedURL.Host == "" {
+		return []string{pageURL}
+	}
+
+	cleanURL := parsedURL.Scheme + "://" + parsedURL.Host + parsedURL.Path
+	if parsedURL.RawQuery != "" {
+		cleanURL += "?" + parsedURL.RawQuery
+	}
+
+	variants := []string{cleanURL}
+	switch parsedURL.Path {
+	case "":
+		variants = append(variants, strings.Replace(cleanURL, parsedURL.Host, parsedURL.Host+"/", 1))
+	case "/":
+		variants = append(variants, strings.Replace(cleanURL, parsedURL.Host+"/", parsedURL.Host, 1))
+	}
+	return variants
+}
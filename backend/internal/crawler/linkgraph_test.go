@@ -0,0 +1,152 @@
+package crawler
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestExtractLinkEdges(t *testing.T) {
+	html := `<html><body>
+		<a href="/about#team">  About   us </a>
+		<a href="https://partner.com/offer" rel="sponsored nofollow">Partner</a>
+		<a href="https://forum.com/thread" rel="UGC">Thread</a>
+		<a href="/home"><img src="/logo.png" alt="Home"></a>
+		<a href="#top">Top</a>
+		<a href="mailto:info@example.com">Mail</a>
+		<a href="/about">About again</a>
+	</body></html>`
+
+	result, err := ParseHTML(strings.NewReader(html), "https://example.com/page")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	expected := []LinkEdge{
+		{URL: "https://example.com/about", AnchorText: "About us", Internal: true},
+		{URL: "https://partner.com/offer", AnchorText: "Partner", Rel: "sponsored nofollow", NoFollow: true, Sponsored: true},
+		{URL: "https://forum.com/thread", AnchorText: "Thread", Rel: "ugc", UGC: true},
+		{URL: "https://example.com/home", AnchorText: "Home", Internal: true, Image: true},
+		{URL: "https://example.com/about", AnchorText: "About again", Internal: true},
+	}
+
+	if len(result.Links) != len(expected) {
+		t.Fatalf("Expected %d link edges, got %d: %+v", len(expected), len(result.Links), result.Links)
+	}
+	for i, edge := range expected {
+		if result.Links[i] != edge {
+			t.Errorf("Edge %d: expected %+v, got %+v", i, edge, result.Links[i])
+		}
+	}
+
+	// Deduplicated link lists are unaffected by the edge list
+	if len(result.InternalLinks) != 2 {
+		t.Errorf("Expected 2 unique internal links, got %d", len(result.InternalLinks))
+	}
+}
+
+func TestLinkEdgeTextTruncation(t *testing.T) {
+	long := strings.Repeat("é", maxAnchorTextLength+50)
+	html := `<html><body><a href="/page" title="` + long + `">` + long + `</a></body></html>`
+
+	result, err := ParseHTML(strings.NewReader(html), "https://example.com/")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	if len(result.Links) != 1 {
+		t.Fatalf("Expected 1 link edge, got %d", len(result.Links))
+	}
+
+	edge := result.Links[0]
+	for name, text := range map[string]string{"anchor text": edge.AnchorText, "title": edge.Title} {
+		if !utf8.ValidString(text) {
+			t.Errorf("Expected the %s to stay valid UTF-8", name)
+		}
+		if count := utf8.RuneCountInString(text); count != maxAnchorTextLength {
+			t.Errorf("Expected the %s to be cut to %d characters, got %d", name, maxAnchorTextLength, count)
+		}
+	}
+}
+
+func TestLinkEdgesBoundStoredValues(t *testing.T) {
+	longURL := "/track?id=" + strings.Repeat("a", maxStoredURLLength)
+	longRel := strings.Repeat("nofollow ", 50)
+	html := `<html><body>
+		<a href="` + longURL + `">Tracked</a>
+		<a href="/page" rel="` + longRel + `">Page</a>
+	</body></html>`
+
+	result, err := ParseHTML(strings.NewReader(html), "https://example.com/")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	if len(result.Links) != 1 {
+		t.Fatalf("Expected the overlong link to be skipped, got %d link edges", len(result.Links))
+	}
+	if rel := result.Links[0].Rel; utf8.RuneCountInString(rel) > maxRelLength {
+		t.Errorf("Expected rel to be cut to %d characters, got %d", maxRelLength, utf8.RuneCountInString(rel))
+	}
+}
+
+func TestLinkIssues(t *testing.T) {
+	html := `<html><body>
+		<a href="/guide" title="Read the guide">Click here</a>
+		<a href="/pricing">Read more &raquo;</a>
+		<a href="/cart"><i class="icon-cart"></i></a>
+		<a href="/account" aria-label="Account"><i class="icon-user"></i></a>
+		<a href="https://partner.com/" target="_blank">Our partner</a>
+		<a href="https://docs.com/" target="_blank" rel="noreferrer">Docs</a>
+		<a href="/help" target="_blank">Help center</a>
+	</body></html>`
+
+	result, err := ParseHTML(strings.NewReader(html), "https://example.com/")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	expected := []struct {
+		issueType string
+		url       string
+	}{
+		{LinkIssueGenericAnchor, "https://example.com/guide"},
+		{LinkIssueGenericAnchor, "https://example.com/pricing"},
+		{LinkIssueEmptyAnchor, "https://example.com/cart"},
+		{LinkIssueUnsafeBlank, "https://partner.com/"},
+	}
+
+	if len(result.LinkIssues) != len(expected) {
+		t.Fatalf("Expected %d link issues, got %d: %+v", len(expected), len(result.LinkIssues), result.LinkIssues)
+	}
+	for i, issue := range expected {
+		if result.LinkIssues[i].Type != issue.issueType || result.LinkIssues[i].URL != issue.url {
+			t.Errorf("Issue %d: expected %s for %s, got %+v", i, issue.issueType, issue.url, result.LinkIssues[i])
+		}
+	}
+
+	// Internal links opening in a new tab are recorded but not reported
+	help := result.Links[len(result.Links)-1]
+	if !help.BlankNoOpener || !help.Internal {
+		t.Errorf("Expected internal link without noopener, got %+v", help)
+	}
+	if result.Links[0].Title != "Read the guide" {
+		t.Errorf("Expected link title to be recorded, got %q", result.Links[0].Title)
+	}
+}
+
+func TestLinkTargetVariants(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected []string
+	}{
+		{"https://example.com", []string{"https://example.com", "https://example.com/"}},
+		{"https://example.com/", []string{"https://example.com/", "https://example.com"}},
+		{"https://example.com/a?b=1#c", []string{"https://example.com/a?b=1"}},
+	}
+
+	for _, test := range tests {
+		variants := LinkTargetVariants(test.url)
+		if strings.Join(variants, " ") != strings.Join(test.expected, " ") {
+			t.Errorf("LinkTargetVariants(%q) = %v, expected %v", test.url, variants, test.expected)
+		}
+	}
+}
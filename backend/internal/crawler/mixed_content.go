@@ -0,0 +1,122 @@
+package crawler
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Mixed content types, following the browser split between blockable and
+// optionally-blockable content
+const (
+	MixedContentActive  = "active"  // Blocked by browsers: scripts, stylesheets, frames, objects, form targets
+	MixedContentPassive = "passive" // Loaded with a warning: images, audio and video
+)
+
+// MixedContentItem is a sub-resource or form target loaded over HTTP from an HTTPS page
+type MixedContentItem struct {
+	URL       string
+	Element   string // Tag name of the offending element
+	Attribute string // Attribute holding the insecure URL
+	Type      string // MixedContentActive or MixedContentPassive
+}
+
+// mixedContentSelectors lists the elements and attributes that load content, with their mixed content type
+var mixedContentSelectors = []struct {
+	selector  string
+	attribute string
+	rel       string // Required rel token for <link> elements
+	srcset    bool   // Attribute holds a srcset list
+	kind      string // Empty when the type depends on the preload destination
+}{
+	{"script[src]", "src", "", false, MixedContentActive},
+	{"link[rel][href]", "href", "stylesheet", false, MixedContentActive},
+	{"link[rel][href]", "href", "preload", false, ""},
+	{"link[rel][href]", "href", "modulepreload", false, MixedContentActive},
+	{"iframe[src]", "src", "", false, MixedContentActive},
+	{"frame[src]", "src", "", false, MixedContentActive},
+	{"object[data]", "data", "", false, MixedContentActive},
+	{"embed[src]", "src", "", false, MixedContentActive},
+	{"form[action]", "action", "", false, MixedContentActive},
+	{"button[formaction], input[formaction]", "formaction", "", false, MixedContentActive},
+	{"img[src]", "src", "", false, MixedContentPassive},
+	{"img[srcset]", "srcset", "", true, MixedContentPassive},
+	{"picture source[srcset]", "srcset", "", true, MixedContentPassive},
+	{"input[type='image'][src]", "src", "", false, MixedContentPassive},
+	{"video[src], audio[src], video source[src], audio source[src]", "src", "", false, MixedContentPassive},
+	{"video[poster]", "poster", "", false, MixedContentPassive},
+	{"link[rel][href]", "href", "icon", false, MixedContentPassive},
+}
+
+// passivePreloadDestinations are the preload "as" values fetched as optionally-blockable content
+var passivePreloadDestinations = map[string]bool{
+	"image": true,
+	"audio": true,
+	"video": true,
+	"track": true,
+}
+
+// preloadMixedContentType classifies a preload by its destination. Preloads without a known
+// passive destination are treated as active, which is how browsers block them.
+func preloadMixedContentType(s *goquery.Selection) string {
+	as, _ := s.Attr("as")
+	if passivePreloadDestinations[strings.ToLower(strings.TrimSpace(as))] {
+		return MixedContentPassive
+	}
+	return MixedContentActive
+}
+
+// extractMixedContent finds HTTP sub-resources and form targets on an HTTPS page
+func extractMixedContent(doc *goquery.Document, baseURL *url.URL) []MixedContentItem {
+	items := []MixedContentItem{}
+	if baseURL.Scheme != "https" {
+		return items
+	}
+
+	seen := make(map[string]bool)
+	record := func(element, attribute, kind, rawURL string) {
+		resolved := resolveURL(baseURL, rawURL)
+		if resolved == "" || !IsValidHTTPURL(resolved) || !strings.HasPrefix(resolved, "http://") {
+			return
+		}
+
+		key := element + " " + attribute + " " + resolved
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+
+		items = append(items, MixedContentItem{
+			URL:       resolved,
+			Element:   element,
+			Attribute: attribute,
+			Type:      kind,
+		})
+	}
+
+	for _, ms := range mixedContentSelectors {
+		doc.Find(ms.selector).Each(func(i int, s *goquery.Selection) {
+			if ms.rel != "" && !hasRelValue(s, ms.rel) {
+				return
+			}
+
+			value, _ := s.Attr(ms.attribute)
+			element := goquery.NodeName(s)
+			kind := ms.kind
+			if kind == "" {
+				kind = preloadMixedContentType(s)
+			}
+
+			if ms.srcset {
+				for _, candidate := range parseSrcset(value) {
+					record(element, ms.attribute, kind, candidate.url)
+				}
+				return
+			}
+			record(element, ms.attribute, kind, value)
+		})
+	}
+
+	return items
+}
@@ -0,0 +1,114 @@
+package crawler
+
+import (
+	"fmt"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Outline issue types
+const (
+	OutlineIssueSkippedLevel = "skipped_level"
+	OutlineIssueEmptyHeading = "empty_heading"
+	OutlineIssueMultipleH1   = "multiple_h1"
+)
+
+// maxHeadingTextLength limits stored heading text
+const maxHeadingTextLength = 500
+
+// Heading is a single entry in the document outline
+type Heading struct {
+	Position int // Index in document order
+	Level    int // 1-6
+	Text     string
+	Depth    int // Nesting depth in the outline, 0 for top-level headings
+	Parent   int // Position of the parent heading, -1 for top-level headings
+}
+
+// OutlineIssue describes a structural problem in the heading outline
+type OutlineIssue struct {
+	Type     string
+	Position int // Position of the offending heading
+	Message  string
+}
+
+// HeadingOutline is the ordered, nested list of headings of a document
+type HeadingOutline struct {
+	Headings []Heading
+	Issues   []OutlineIssue
+}
+
+// extractHeadingOutline builds the heading outline of a document
+func extractHeadingOutline(doc *goquery.Document) HeadingOutline {
+	outline := HeadingOutline{
+		Headings: []Heading{},
+		Issues:   []OutlineIssue{},
+	}
+
+	// Stack of positions of the currently open headings
+	var stack []int
+	h1Seen := false
+
+	doc.Find("h1, h2, h3, h4, h5, h6").Each(func(i int, s *goquery.Selection) {
+		level := int(goquery.NodeName(s)[1] - '0')
+
+		text := SanitizeText(s.Text())
+		if text == "" {
+			// Image-only headings are labelled by their alt text
+			if alt, exists := s.Find("img[alt]").First().Attr("alt"); exists {
+				text = SanitizeText(alt)
+			}
+		}
+		text = truncateRunes(text, maxHeadingTextLength)
+
+		for len(stack) > 0 && outline.Headings[stack[len(stack)-1]].Level >= level {
+			stack = stack[:len(stack)-1]
+		}
+
+		heading := Heading{
+			Position: i,
+			Level:    level,
+			Text:     text,
+			Depth:    len(stack),
+			Parent:   -1,
+		}
+		if len(stack) > 0 {
+			heading.Parent = stack[len(stack)-1]
+		}
+
+		if i > 0 {
+			previous := outline.Headings[i-1].Level
+			if level > previous+1 {
+				outline.Issues = append(outline.Issues, OutlineIssue{
+					Type:     OutlineIssueSkippedLevel,
+					Position: i,
+					Message:  fmt.Sprintf("Heading level skipped: H%d followed by H%d", previous, level),
+				})
+			}
+		}
+
+		if text == "" {
+			outline.Issues = append(outline.Issues, OutlineIssue{
+				Type:     OutlineIssueEmptyHeading,
+				Position: i,
+				Message:  fmt.Sprintf("H%d heading has no text", level),
+			})
+		}
+
+		if level == 1 {
+			if h1Seen {
+				outline.Issues = append(outline.Issues, OutlineIssue{
+					Type:     OutlineIssueMultipleH1,
+					Position: i,
+					Message:  "Additional H1 heading found",
+				})
+			}
+			h1Seen = true
+		}
+
+		outline.Headings = append(outline.Headings, heading)
+		stack = append(stack, i)
+	})
+
+	return outline
+}
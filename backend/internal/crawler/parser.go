@@ -20,9 +20,37 @@ type ParseResult struct {
 	ExternalLinks      []string
 	HasLoginForm       bool
 	LoginFormConfidence float64 // Confidence score 0.0-1.0 for login form detection
+	CanonicalURL       string
+	CanonicalCount     int // Number of canonical link elements found (more than one is an error)
+	Hreflangs          []HreflangLink
+	Robots             RobotsDirectives // Directives from <meta name="robots"> only
+	XRobotsTag         RobotsDirectives // Directives from the X-Robots-Tag header, set by the crawler
+	MetaDescriptionCount int
+	MetaNames          map[string]string // Every named meta tag, for technology detection
+	Outline            HeadingOutline
+	Images             []ImageInfo
+	ImageIssues        []ImageIssue // Markup problems such as missing alt text
+	Resources          []ResourceRef // Scripts, stylesheets, iframes, media, objects and icons
+	AnchorTargets      map[string]bool // Element ids and a[name] values fragments can point to
+	Fragments          []FragmentRef   // Links with #fragments to this page or other internal pages
+	MixedContent       []MixedContentItem // HTTP resources and form targets on HTTPS pages
+	Links              []LinkEdge         // Every link element with its anchor text and rel attributes
+	LinkIssues         []LinkIssue        // Generic or empty anchors and unsafe target="_blank" links
+	Privacy            PrivacyReport      // Third-party scripts, iframes, pixels and links, and the consent banner
+	Forms              []FormInfo         // Every form with its fields, target and purpose
+	FormIssues         []FormIssue        // Password forms submitted over HTTP or to another origin
+	Content            ContentMetrics // Word and sentence counts, readability, text-to-HTML ratio and language
+	HTMLSize           int64             // Bytes of HTML parsed
+	Document           *goquery.Document // Parsed document, for page analyzers
 	Error              string
 }
 
+// HreflangLink represents a <link rel="alternate" hreflang="..."> element
+type HreflangLink struct {
+	Lang string
+	URL  string
+}
+
 // LoginFormAnalysis contains detailed analysis of login form detection
 type LoginFormAnalysis struct {
 	HasLoginForm bool
@@ -38,13 +66,15 @@ func ParseHTML(htmlReader io.Reader, baseURL string) (*ParseResult, error) {
 		return nil, fmt.Errorf("failed to parse base URL: %w", err)
 	}
 
-	// Load HTML document
-	doc, err := goquery.NewDocumentFromReader(htmlReader)
+	// Load HTML document, counting its size for the text-to-HTML ratio
+	counter := &countingReader{reader: htmlReader}
+	doc, err := goquery.NewDocumentFromReader(counter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse HTML document: %w", err)
 	}
 
 	result := &ParseResult{
+		Document:      doc,
 		HeadingCounts: make(map[string]int),
 		MetaTags:      make(map[string]string),
 		InternalLinks: []string{},
@@ -62,9 +92,43 @@ func ParseHTML(htmlReader io.Reader, baseURL string) (*ParseResult, error) {
 
 	// Extract meta tags
 	result.MetaTags = extractMetaTags(doc)
+	result.MetaDescriptionCount = countMetaDescriptions(doc)
+	result.MetaNames = extractMetaNames(doc)
+
+	// Build the heading outline
+	result.Outline = extractHeadingOutline(doc)
+
+	// Collect the image inventory and alt coverage
+	result.Images, result.ImageIssues = extractImages(doc, parsedBaseURL)
+
+	// Measure the main content without boilerplate
+	result.HTMLSize = counter.count
+	result.Content = extractContentMetrics(doc, counter.count)
+
+	// Extract canonical, hreflang and robots directives
+	result.CanonicalURL, result.CanonicalCount = extractCanonical(doc, parsedBaseURL)
+	result.Hreflangs = extractHreflangs(doc, parsedBaseURL)
+	result.Robots = extractRobotsMeta(doc)
 
 	// Extract and classify links
 	result.InternalLinks, result.ExternalLinks = extractLinks(doc, parsedBaseURL)
+	result.Links, result.LinkIssues = extractLinkEdges(doc, parsedBaseURL)
+
+	// Extract sub-resources
+	result.Resources = extractResources(doc, parsedBaseURL)
+
+	// Extract fragment links and the anchors they can resolve to
+	result.AnchorTargets = extractAnchorTargets(doc)
+	result.Fragments = extractFragments(doc, parsedBaseURL)
+
+	// Detect mixed content on HTTPS pages
+	result.MixedContent = extractMixedContent(doc, parsedBaseURL)
+
+	// Inventory third parties and whether they load before the consent banner
+	result.Privacy = extractPrivacy(doc, parsedBaseURL)
+
+	// Catalogue forms and flag insecure password forms
+	result.Forms, result.FormIssues = extractForms(doc, parsedBaseURL)
 
 	// Detect login forms with confidence scoring
 	loginAnalysis := detectLoginFormWithConfidence(doc)
@@ -145,6 +209,17 @@ func extractHeadingCounts(doc *goquery.Document) map[string]int {
 	return counts
 }
 
+// countMetaDescriptions counts <meta name="description"> elements
+func countMetaDescriptions(doc *goquery.Document) int {
+	count := 0
+	doc.Find("meta[name]").Each(func(i int, s *goquery.Selection) {
+		if name, _ := s.Attr("name"); strings.ToLower(strings.TrimSpace(name)) == "description" {
+			count++
+		}
+	})
+	return count
+}
+
 // extractMetaTags extracts important meta tags
 func extractMetaTags(doc *goquery.Document) map[string]string {
 	metaTags := make(map[string]string)
@@ -192,41 +267,112 @@ func extractMetaTags(doc *goquery.Document) map[string]string {
 	return metaTags
 }
 
-// extractLinks extracts and classifies links as internal or external
-func extractLinks(doc *goquery.Document, baseURL *url.URL) (internal []string, external []string) {
-	seenInternal := make(map[string]bool)
-	seenExternal := make(map[string]bool)
+// extractCanonical extracts the resolved canonical URL and the number of canonical elements
+func extractCanonical(doc *goquery.Document, baseURL *url.URL) (string, int) {
+	var canonical string
+	count := 0
 
-	doc.Find("a[href]").Each(func(i int, s *goquery.Selection) {
-		href, exists := s.Attr("href")
-		if !exists || href == "" {
+	doc.Find("link[rel][href]").Each(func(i int, s *goquery.Selection) {
+		if !hasRelValue(s, "canonical") {
 			return
 		}
+		count++
 
-		// Clean and normalize the URL
-		href = strings.TrimSpace(href)
-		
-		// Skip javascript:, mailto:, tel:, etc.
-		if strings.HasPrefix(href, "javascript:") || 
-		   strings.HasPrefix(href, "mailto:") || 
-		   strings.HasPrefix(href, "tel:") ||
-		   strings.HasPrefix(href, "#") {
+		// The first canonical wins, as it does for search engines
+		if canonical != "" {
 			return
 		}
+		href, _ := s.Attr("href")
+		if resolved := resolveURL(baseURL, href); resolved != "" {
+			canonical = resolved
+		}
+	})
 
-		// Parse the link URL
-		linkURL, err := url.Parse(href)
-		if err != nil {
+	return canonical, count
+}
+
+// extractHreflangs extracts alternate language links
+func extractHreflangs(doc *goquery.Document, baseURL *url.URL) []HreflangLink {
+	hreflangs := []HreflangLink{}
+
+	doc.Find("link[hreflang][href]").Each(func(i int, s *goquery.Selection) {
+		if !hasRelValue(s, "alternate") {
 			return
 		}
 
-		// Resolve relative URLs
-		resolvedURL := baseURL.ResolveReference(linkURL)
-		
-		// Remove fragment for deduplication
-		cleanURL := resolvedURL.Scheme + "://" + resolvedURL.Host + resolvedURL.Path
-		if resolvedURL.RawQuery != "" {
-			cleanURL += "?" + resolvedURL.RawQuery
+		lang, _ := s.Attr("hreflang")
+		href, _ := s.Attr("href")
+		resolved := resolveURL(baseURL, href)
+		if resolved == "" {
+			return
+		}
+
+		hreflangs = append(hreflangs, HreflangLink{
+			Lang: strings.ToLower(strings.TrimSpace(lang)),
+			URL:  resolved,
+		})
+	})
+
+	return hreflangs
+}
+
+// extractRobotsMeta parses every <meta name="robots"> element into a single set of directives
+func extractRobotsMeta(doc *goquery.Document) RobotsDirectives {
+	var directives RobotsDirectives
+
+	doc.Find("meta[name][content]").Each(func(i int, s *goquery.Selection) {
+		name, _ := s.Attr("name")
+		if strings.ToLower(strings.TrimSpace(name)) != "robots" {
+			return
+		}
+		content, _ := s.Attr("content")
+		directives = directives.Merge(ParseRobotsDirectives(content))
+	})
+
+	return directives
+}
+
+// hasRelValue checks whether an element's space-separated rel attribute contains value
+func hasRelValue(s *goquery.Selection, value string) bool {
+	rel, exists := s.Attr("rel")
+	if !exists {
+		return false
+	}
+	for _, token := range strings.Fields(strings.ToLower(rel)) {
+		if token == value {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveURL resolves href against the base URL and strips the fragment
+func resolveURL(baseURL *url.URL, href string) string {
+	href = strings.TrimSpace(href)
+	if href == "" {
+		return ""
+	}
+
+	linkURL, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+
+	resolved := baseURL.ResolveReference(linkURL)
+	resolved.Fragment = ""
+	return resolved.String()
+}
+
+// extractLinks extracts and classifies links as internal or external
+func extractLinks(doc *goquery.Document, baseURL *url.URL) (internal []string, external []string) {
+	seenInternal := make(map[string]bool)
+	seenExternal := make(map[string]bool)
+
+	doc.Find("a[href]").Each(func(i int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		cleanURL, resolvedURL, ok := resolveLinkHref(baseURL, href)
+		if !ok {
+			return
 		}
 
 		// Classify as internal or external
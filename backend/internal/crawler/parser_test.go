@@ -1,8 +1,14 @@
 package crawler
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 )
 
 func TestParseHTML(t *testing.T) {
@@ -98,6 +104,138 @@ func TestParseHTML(t *testing.T) {
 	}
 }
 
+func TestParseHTMLIndexing(t *testing.T) {
+	sampleHTML := `
+<html>
+<head>
+    <title>Indexing</title>
+    <link rel="canonical" href="/docs/">
+    <link rel="Canonical" href="https://other.com/docs/">
+    <link rel="alternate" hreflang="en-US" href="/en/docs/">
+    <link rel="alternate" hreflang="x-default" href="https://example.com/docs/">
+    <meta name="ROBOTS" content="noindex, follow">
+</head>
+<body></body>
+</html>`
+
+	result, err := ParseHTML(strings.NewReader(sampleHTML), "https://example.com/docs/page")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	if result.CanonicalURL != "https://example.com/docs/" {
+		t.Errorf("Expected first canonical to be resolved, got '%s'", result.CanonicalURL)
+	}
+	if result.CanonicalCount != 2 {
+		t.Errorf("Expected 2 canonical elements, got %d", result.CanonicalCount)
+	}
+
+	if len(result.Hreflangs) != 2 {
+		t.Fatalf("Expected 2 hreflang links, got %d", len(result.Hreflangs))
+	}
+	if result.Hreflangs[0].Lang != "en-us" || result.Hreflangs[0].URL != "https://example.com/en/docs/" {
+		t.Errorf("Unexpected hreflang link: %+v", result.Hreflangs[0])
+	}
+
+	if !result.Robots.NoIndex || result.Robots.NoFollow {
+		t.Errorf("Expected noindex without nofollow, got %+v", result.Robots)
+	}
+}
+
+func TestExtractHeadingOutline(t *testing.T) {
+	sampleHTML := `
+<html>
+<body>
+    <h1>Guide</h1>
+    <h2>Install</h2>
+    <h4>Linux</h4>
+    <h2> </h2>
+    <h3><img src="/logo.png" alt="Logo"></h3>
+    <h1>Second title</h1>
+</body>
+</html>`
+
+	result, err := ParseHTML(strings.NewReader(sampleHTML), "https://example.com")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	headings := result.Outline.Headings
+	if len(headings) != 6 {
+		t.Fatalf("Expected 6 headings, got %d", len(headings))
+	}
+
+	expected := []struct {
+		level  int
+		text   string
+		depth  int
+		parent int
+	}{
+		{1, "Guide", 0, -1},
+		{2, "Install", 1, 0},
+		{4, "Linux", 2, 1},
+		{2, "", 1, 0},
+		{3, "Logo", 2, 3},
+		{1, "Second title", 0, -1},
+	}
+
+	for i, want := range expected {
+		got := headings[i]
+		if got.Level != want.level || got.Text != want.text || got.Depth != want.depth || got.Parent != want.parent {
+			t.Errorf("Heading %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+
+	issues := make(map[string]int)
+	for _, issue := range result.Outline.Issues {
+		issues[issue.Type] = issue.Position
+	}
+
+	if position, exists := issues[OutlineIssueSkippedLevel]; !exists || position != 2 {
+		t.Errorf("Expected skipped level at position 2, got %+v", result.Outline.Issues)
+	}
+	if position, exists := issues[OutlineIssueEmptyHeading]; !exists || position != 3 {
+		t.Errorf("Expected empty heading at position 3, got %+v", result.Outline.Issues)
+	}
+	if position, exists := issues[OutlineIssueMultipleH1]; !exists || position != 5 {
+		t.Errorf("Expected multiple H1 at position 5, got %+v", result.Outline.Issues)
+	}
+}
+
+func TestHeadingTextTruncation(t *testing.T) {
+	sampleHTML := "<html><body><h1>" + strings.Repeat("é", maxHeadingTextLength+100) + "</h1></body></html>"
+
+	result, err := ParseHTML(strings.NewReader(sampleHTML), "https://example.com")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	text := result.Outline.Headings[0].Text
+	if !utf8.ValidString(text) || utf8.RuneCountInString(text) != maxHeadingTextLength {
+		t.Errorf("Expected %d valid characters, got %d (valid=%v)", maxHeadingTextLength, utf8.RuneCountInString(text), utf8.ValidString(text))
+	}
+}
+
+func TestConvertToHeadingsKeepsFirstParent(t *testing.T) {
+	result, err := ParseHTML(strings.NewReader("<html><body><h1>Page</h1><h2>First</h2><h2>Second</h2></body></html>"), "https://example.com")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	headings, _ := NewCrawlerService(nil).ConvertToHeadings(&CrawlResult{Outline: result.Outline}, 1)
+	if len(headings) != 3 {
+		t.Fatalf("Expected 3 headings, got %d", len(headings))
+	}
+	if headings[0].ParentPosition != -1 {
+		t.Errorf("Expected the H1 to be top-level, got parent %d", headings[0].ParentPosition)
+	}
+	for _, heading := range headings[1:] {
+		if heading.ParentPosition != 0 {
+			t.Errorf("Expected %q to be a child of the H1, got parent %d", heading.Text, heading.ParentPosition)
+		}
+	}
+}
+
 func TestValidateURL(t *testing.T) {
 	crawler := NewCrawlerService(nil)
 
@@ -127,4 +265,106 @@ func TestValidateURL(t *testing.T) {
 			t.Errorf("Expected invalid URL '%s' to fail validation", url)
 		}
 	}
-} 
\ No newline at end of file
+} 
+
+func TestExtractMixedContent(t *testing.T) {
+	sampleHTML := `
+<html>
+<head>
+    <script src="http://cdn.example.com/app.js"></script>
+    <link rel="stylesheet" href="http://cdn.example.com/site.css">
+    <link rel="stylesheet" href="//cdn.example.com/secure.css">
+    <link rel="icon" href="http://example.com/favicon.ico">
+    <link rel="preload" href="http://cdn.example.com/hero.jpg" as="image">
+    <link rel="preload" href="http://cdn.example.com/font.woff2" as="font" crossorigin>
+    <link rel="preload" href="http://cdn.example.com/data.json">
+</head>
+<body>
+    <img src="http://images.example.com/a.png" srcset="http://images.example.com/a@2x.png 2x, /b.png 1x">
+    <iframe src="http://widgets.example.com/frame"></iframe>
+    <video src="http://media.example.com/clip.mp4"></video>
+    <form action="http://example.com/login" method="post"></form>
+    <form action="/search"></form>
+    <a href="http://example.com/plain-link">Not mixed content</a>
+</body>
+</html>`
+
+	result, err := ParseHTML(strings.NewReader(sampleHTML), "https://example.com/")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	expected := map[string]string{
+		"http://cdn.example.com/app.js":      MixedContentActive,
+		"http://cdn.example.com/site.css":    MixedContentActive,
+		"http://example.com/favicon.ico":     MixedContentPassive,
+		"http://cdn.example.com/hero.jpg":    MixedContentPassive,
+		"http://cdn.example.com/font.woff2":  MixedContentActive,
+		"http://cdn.example.com/data.json":   MixedContentActive,
+		"http://images.example.com/a.png":    MixedContentPassive,
+		"http://images.example.com/a@2x.png": MixedContentPassive,
+		"http://widgets.example.com/frame":   MixedContentActive,
+		"http://media.example.com/clip.mp4":  MixedContentPassive,
+		"http://example.com/login":           MixedContentActive,
+	}
+
+	if len(result.MixedContent) != len(expected) {
+		t.Errorf("Expected %d mixed content items, got %d: %+v", len(expected), len(result.MixedContent), result.MixedContent)
+	}
+	for _, item := range result.MixedContent {
+		if expected[item.URL] != item.Type {
+			t.Errorf("Expected %s to be %s mixed content, got %s", item.URL, expected[item.URL], item.Type)
+		}
+		if item.URL == "http://example.com/login" && item.Element != "form" {
+			t.Errorf("Expected form element for form target, got %s", item.Element)
+		}
+	}
+
+	// HTTP pages cannot have mixed content
+	result, err = ParseHTML(strings.NewReader(sampleHTML), "http://example.com/")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	if len(result.MixedContent) != 0 {
+		t.Errorf("Expected no mixed content on an HTTP page, got %d", len(result.MixedContent))
+	}
+}
+
+func TestMixedContentAfterRedirect(t *testing.T) {
+	// An HTTPS address redirecting to an HTTP page: the page itself is not secure
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><body><img src="http://images.example.com/a.png"></body></html>`)
+	}))
+	defer page.Close()
+
+	secure := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, page.URL+"/page", http.StatusFound)
+	}))
+	defer secure.Close()
+
+	crawler := NewCrawlerService(nil)
+	crawler.client.Transport = secure.Client().Transport
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result := crawler.crawlURL(ctx, secure.URL+"/", nil)
+	if result.Error != "" {
+		t.Fatalf("Crawl failed: %s", result.Error)
+	}
+	if len(result.ParseResult.MixedContent) != 0 {
+		t.Errorf("Expected no mixed content on a page redirected to HTTP, got %+v", result.ParseResult.MixedContent)
+	}
+}
+
+func TestConvertTruncatesMetaDescription(t *testing.T) {
+	crawlResult := &CrawlResult{MetaTags: map[string]string{"description": strings.Repeat("é", 1200)}}
+
+	analysis := NewCrawlerService(nil).ConvertToAnalysisResult(crawlResult, 1)
+	if !utf8.ValidString(analysis.MetaDescription) {
+		t.Error("Expected the meta description to stay valid UTF-8")
+	}
+	if count := utf8.RuneCountInString(analysis.MetaDescription); count != 1000 {
+		t.Errorf("Expected the meta description to be cut to 1000 characters, got %d", count)
+	}
+}
@@ -0,0 +1,249 @@
+package crawler
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/publicsuffix"
+)
+
+// Third-party categories; domains missing from the tracker list are CategoryOther
+const (
+	CategoryAdvertising = "advertising"
+	CategoryAnalytics   = "analytics"
+	CategorySocial      = "social"
+	CategoryCDN         = "cdn"
+	CategoryConsent     = "consent" // Consent management platforms
+	CategoryOther       = "other"
+)
+
+// Elements through which a page uses a third party
+const (
+	ThirdPartyScript = "script"
+	ThirdPartyIframe = "iframe"
+	ThirdPartyPixel  = "pixel"
+	ThirdPartyLink   = "link"
+)
+
+// elementImage marks an image that is only reported when it is served by a tracker
+const elementImage = "img"
+
+// Limits on the third parties recorded per page
+const (
+	maxThirdParties = 500
+	maxDomainLength = 255 // Longer host names are not valid
+)
+
+// consentBannerSelector matches the markup of common cookie consent banners
+const consentBannerSelector = "#onetrust-banner-sdk, #onetrust-consent-sdk, #CybotCookiebotDialog, #usercentrics-root, " +
+	"#didomi-host, #cookie-law-info-bar, #cmplz-cookiebanner-container, .cc-window, .cky-consent-container, " +
+	"[id*=cookie-banner], [id*=cookie-consent], [id*=cookieconsent], [id*=cookie-notice], [id*=consent-banner], " +
+	"[class*=cookie-banner], [class*=cookie-consent], [class*=cookieconsent], [class*=cookie-notice], [class*=consent-banner]"
+
+// defaultTrackerList maps categories to companies and the domains they serve from, in the
+// format of the Disconnect tracker list. A domain may carry a path, such as facebook.com/tr.
+//
+//go:embed signatures/trackers.json
+var defaultTrackerList []byte
+
+// trackerEntry is a domain of the tracker list
+type trackerEntry struct {
+	domain   string
+	path     string
+	company  string
+	category string
+}
+
+// trackerEntries is the parsed tracker list
+var trackerEntries = mustParseTrackerList(defaultTrackerList)
+
+// ThirdParty is an external domain a page loads or links to through one kind of element
+type ThirdParty struct {
+	Domain        string // Registrable domain, e.g. doubleclick.net
+	Company       string // Owner from the tracker list, empty when unknown
+	Category      string
+	Element       string // ThirdPartyScript, ThirdPartyIframe, ThirdPartyPixel or ThirdPartyLink
+	URL           string // First URL seen
+	Count         int
+	BeforeConsent bool // Loaded before any consent banner in the markup, or with no banner at all
+}
+
+// IsTracker reports whether the third party is in a tracking category
+func (t ThirdParty) IsTracker() bool {
+	return t.Category == CategoryAdvertising || t.Category == CategoryAnalytics || t.Category == CategorySocial
+}
+
+// PrivacyReport is the third-party inventory of a page and its consent banner
+type PrivacyReport struct {
+	ConsentBanner   bool
+	ConsentProvider string // Consent platform of the banner, empty when unknown or absent
+	ThirdParties    []ThirdParty
+}
+
+// TrackersBeforeConsent counts the trackers loaded before consent can be given
+func (r PrivacyReport) TrackersBeforeConsent() int {
+	count := 0
+	for _, party := range r.ThirdParties {
+		if party.IsTracker() && party.BeforeConsent {
+			count++
+		}
+	}
+	return count
+}
+
+// mustParseTrackerList parses the embedded tracker list
+func mustParseTrackerList(data []byte) []trackerEntry {
+	var list map[string]map[string][]string
+	if err := json.Unmarshal(data, &list); err != nil {
+		panic("invalid embedded tracker list: " + err.Error())
+	}
+
+	var entries []trackerEntry
+	for category, companies := range list {
+		for company, domains := range companies {
+			for _, domain := range domains {
+				host, path, _ := strings.Cut(domain, "/")
+				if path != "" {
+					path = "/" + path
+				}
+				entries = append(entries, trackerEntry{domain: host, path: path, company: company, category: category})
+			}
+		}
+	}
+	return entries
+}
+
+// classifyThirdParty finds the most specific tracker list entry for a URL
+func classifyThirdParty(u *url.URL) (company, category string) {
+	host := strings.ToLower(u.Hostname())
+	best := -1
+	for _, entry := range trackerEntries {
+		if host != entry.domain && !strings.HasSuffix(host, "."+entry.domain) {
+			continue
+		}
+		if entry.path != "" && !strings.HasPrefix(u.Path, entry.path) {
+			continue
+		}
+		if specificity := len(entry.domain) + len(entry.path); specificity > best {
+			best = specificity
+			company, category = entry.company, entry.category
+		}
+	}
+	if best < 0 {
+		return "", CategoryOther
+	}
+	return company, category
+}
+
+// registrableDomain returns the domain a host was registered under, e.g. example.co.uk for www.example.co.uk
+func registrableDomain(host string) string {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if domain, err := publicsuffix.EffectiveTLDPlusOne(host); err == nil {
+		return domain
+	}
+	return host
+}
+
+// extractPrivacy inventories the external scripts, iframes, pixels and links of a page in
+// document order, noting which load before the first consent banner
+func extractPrivacy(doc *goquery.Document, baseURL *url.URL) PrivacyReport {
+	report := PrivacyReport{ThirdParties: []ThirdParty{}}
+	firstParty := registrableDomain(baseURL.Hostname())
+	index := make(map[string]int) // Domain and element to position in ThirdParties
+
+	record := func(element, rawURL string, loads bool) {
+		resolved := resolveURL(baseURL, rawURL)
+		if resolved == "" || !IsValidHTTPURL(resolved) {
+			return
+		}
+		parsed, err := url.Parse(resolved)
+		if err != nil {
+			return
+		}
+		domain := registrableDomain(parsed.Hostname())
+		if domain == firstParty || len(domain) > maxDomainLength {
+			return
+		}
+
+		company, category := classifyThirdParty(parsed)
+		if category == CategoryConsent && !report.ConsentBanner {
+			report.ConsentBanner = true
+			report.ConsentProvider = company
+		}
+		// Visible images are only reported when served by advertising or analytics domains
+		if element == elementImage {
+			if category != CategoryAdvertising && category != CategoryAnalytics {
+				return
+			}
+			element = ThirdPartyPixel
+		}
+
+		key := domain + " " + element
+		if i, exists := index[key]; exists {
+			report.ThirdParties[i].Count++
+			if loads && !report.ConsentBanner {
+				report.ThirdParties[i].BeforeConsent = true
+			}
+			return
+		}
+		if len(report.ThirdParties) >= maxThirdParties {
+			return
+		}
+		index[key] = len(report.ThirdParties)
+		report.ThirdParties = append(report.ThirdParties, ThirdParty{
+			Domain:        domain,
+			Company:       company,
+			Category:      category,
+			Element:       element,
+			URL:           truncateRunes(resolved, maxStoredURLLength),
+			Count:         1,
+			BeforeConsent: loads && !report.ConsentBanner,
+		})
+	}
+
+	// Selector groups match in document order
+	doc.Find("script[src], iframe[src], img[src], a[href], " + consentBannerSelector).Each(func(i int, s *goquery.Selection) {
+		if !report.ConsentBanner && s.Is(consentBannerSelector) {
+			report.ConsentBanner = true
+		}
+
+		switch goquery.NodeName(s) {
+		case "script":
+			// Consent platforms hold back scripts with a non-JavaScript type until consent is given
+			scriptType := strings.ToLower(strings.TrimSpace(s.AttrOr("type", "")))
+			executes := scriptType == "" || strings.Contains(scriptType, "javascript") || scriptType == "module"
+			record(ThirdPartyScript, s.AttrOr("src", ""), executes)
+		case "iframe":
+			record(ThirdPartyIframe, s.AttrOr("src", ""), true)
+		case "img":
+			element := elementImage
+			if isTrackingPixel(s) {
+				element = ThirdPartyPixel
+			}
+			record(element, s.AttrOr("src", ""), true)
+		case "a":
+			record(ThirdPartyLink, s.AttrOr("href", ""), false)
+		}
+	})
+
+	return report
+}
+
+// isTrackingPixel reports whether an image is invisible, as 1x1 or hidden beacons are
+func isTrackingPixel(s *goquery.Selection) bool {
+	if _, hidden := s.Attr("hidden"); hidden {
+		return true
+	}
+	style := strings.ReplaceAll(strings.ToLower(s.AttrOr("style", "")), " ", "")
+	if strings.Contains(style, "display:none") || strings.Contains(style, "visibility:hidden") {
+		return true
+	}
+
+	width, widthErr := strconv.Atoi(strings.TrimSpace(s.AttrOr("width", "")))
+	height, heightErr := strconv.Atoi(strings.TrimSpace(s.AttrOr("height", "")))
+	return widthErr == nil && heightErr == nil && width <= 1 && height <= 1
+}
@@ -0,0 +1,129 @@
+package crawler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractPrivacy(t *testing.T) {
+	html := `<html><head>
+		<script src="https://www.googletagmanager.com/gtag/js?id=G-123"></script>
+		<script src="https://cdn.jsdelivr.net/npm/alpinejs@3/dist/cdn.min.js"></script>
+		<script src="/assets/app.js"></script>
+		<script src="https://static.example.com/site.js"></script>
+	</head><body>
+		<img src="https://www.facebook.com/tr?id=1&ev=PageView" width="1" height="1" style="display:none">
+		<img src="https://images.unsplash.com/photo.jpg" alt="Photo">
+		<a href="https://twitter.com/example">Follow us</a>
+		<a href="https://partner.org/page">Partner</a>
+		<a href="https://partner.org/other">Partner again</a>
+		<div id="cookie-banner">We use cookies</div>
+		<script type="text/plain" data-category="marketing" src="https://connect.facebook.net/en_US/fbevents.js"></script>
+		<iframe src="https://www.youtube.com/embed/abc"></iframe>
+	</body></html>`
+
+	parseResult, err := ParseHTML(strings.NewReader(html), "https://www.example.com/")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	report := parseResult.Privacy
+
+	if !report.ConsentBanner {
+		t.Error("Expected the consent banner to be detected")
+	}
+
+	type expectation struct {
+		category      string
+		company       string
+		count         int
+		beforeConsent bool
+	}
+	expected := map[string]expectation{
+		"googletagmanager.com script": {CategoryAnalytics, "Google", 1, true},
+		"jsdelivr.net script":         {CategoryCDN, "jsDelivr", 1, true},
+		"facebook.com pixel":          {CategoryAdvertising, "Meta", 1, true},
+		"twitter.com link":            {CategorySocial, "Twitter", 1, false},
+		"partner.org link":            {CategoryOther, "", 2, false},
+		"facebook.net script":         {CategoryAdvertising, "Meta", 1, false}, // Held back until consent
+		"youtube.com iframe":          {CategorySocial, "YouTube", 1, false},   // After the banner
+	}
+
+	found := make(map[string]ThirdParty)
+	for _, party := range report.ThirdParties {
+		found[party.Domain+" "+party.Element] = party
+	}
+	if len(found) != len(expected) {
+		t.Errorf("Expected %d third parties, got %d: %+v", len(expected), len(found), report.ThirdParties)
+	}
+	for key, want := range expected {
+		party, ok := found[key]
+		if !ok {
+			t.Errorf("Expected third party %q", key)
+			continue
+		}
+		if party.Category != want.category || party.Company != want.company || party.Count != want.count ||
+			party.BeforeConsent != want.beforeConsent {
+			t.Errorf("%s: expected %+v, got %+v", key, want, party)
+		}
+	}
+
+	// GTM and the Facebook pixel; CDN scripts and links aren't trackers loaded before consent
+	if got := report.TrackersBeforeConsent(); got != 2 {
+		t.Errorf("Expected 2 trackers before consent, got %d", got)
+	}
+}
+
+func TestExtractPrivacyConsentPlatform(t *testing.T) {
+	html := `<html><head>
+		<script src="https://cdn.cookielaw.org/scripttemplates/otSDKStub.js"></script>
+		<script src="https://www.google-analytics.com/analytics.js"></script>
+	</head><body></body></html>`
+
+	parseResult, err := ParseHTML(strings.NewReader(html), "https://example.co.uk/")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	report := parseResult.Privacy
+
+	if !report.ConsentBanner || report.ConsentProvider != "OneTrust" {
+		t.Errorf("Expected a OneTrust consent banner, got %+v", report)
+	}
+	if got := report.TrackersBeforeConsent(); got != 0 {
+		t.Errorf("Expected no trackers before consent, got %d", got)
+	}
+
+	// Without any banner, every loaded tracker counts
+	parseResult, err = ParseHTML(strings.NewReader(`<html><body>
+		<script src="https://www.google-analytics.com/analytics.js"></script>
+		<a href="https://shop.example.co.uk/">Shop</a>
+	</body></html>`), "https://example.co.uk/")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	report = parseResult.Privacy
+	if report.ConsentBanner || report.TrackersBeforeConsent() != 1 || len(report.ThirdParties) != 1 {
+		t.Errorf("Expected one tracker without a banner and subdomains as first party, got %+v", report)
+	}
+}
+
+func TestExtractPrivacyBoundsStoredValues(t *testing.T) {
+	longURL := "https://www.google-analytics.com/collect?v=" + strings.Repeat("a", maxStoredURLLength)
+	longHost := "https://" + strings.Repeat("a", 300) + ".com/widget.js"
+	html := `<html><body>
+		<script src="` + longURL + `"></script>
+		<script src="` + longHost + `"></script>
+	</body></html>`
+
+	parseResult, err := ParseHTML(strings.NewReader(html), "https://example.com/")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	thirdParties := parseResult.Privacy.ThirdParties
+	if len(thirdParties) != 1 {
+		t.Fatalf("Expected the overlong domain to be skipped, got %+v", thirdParties)
+	}
+	if len(thirdParties[0].URL) > maxStoredURLLength {
+		t.Errorf("Expected the URL to be cut to %d characters, got %d", maxStoredURLLength, len(thirdParties[0].URL))
+	}
+}
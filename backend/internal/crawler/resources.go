@@ -0,0 +1,98 @@
+package crawler
+
+import (
+	"net/url"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Resource types recorded on broken links
+const (
+	ResourceLink       = "link"
+	ResourceImage      = "image"
+	ResourceScript     = "script"
+	ResourceStylesheet = "stylesheet"
+	ResourceIframe     = "iframe"
+	ResourceMedia      = "media"
+	ResourceObject     = "object"
+	ResourceIcon       = "icon"
+)
+
+// ResourceRef is a sub-resource referenced by a page
+type ResourceRef struct {
+	URL  string
+	Type string
+}
+
+// resourceSelectors maps element selectors to the attribute holding the URL and the resource type
+var resourceSelectors = []struct {
+	selector     string
+	attribute    string
+	resourceType string
+	rel          string // Required rel token, empty when not applicable
+}{
+	{"script[src]", "src", ResourceScript, ""},
+	{"link[rel][href]", "href", ResourceStylesheet, "stylesheet"},
+	{"link[rel][href]", "href", ResourceIcon, "icon"},
+	{"link[rel][href]", "href", ResourceIcon, "apple-touch-icon"},
+	{"iframe[src]", "src", ResourceIframe, ""},
+	{"video[src], audio[src], video source[src], audio source[src], track[src]", "src", ResourceMedia, ""},
+	{"video[poster]", "poster", ResourceMedia, ""},
+	{"object[data]", "data", ResourceObject, ""},
+	{"embed[src]", "src", ResourceObject, ""},
+}
+
+// extractResources extracts scripts, stylesheets, iframes, media, objects and icons
+func extractResources(doc *goquery.Document, baseURL *url.URL) []ResourceRef {
+	resources := []ResourceRef{}
+	seen := make(map[string]bool)
+
+	for _, rs := range resourceSelectors {
+		doc.Find(rs.selector).Each(func(i int, s *goquery.Selection) {
+			if rs.rel != "" && !hasRelValue(s, rs.rel) {
+				return
+			}
+
+			value, _ := s.Attr(rs.attribute)
+			resolved := resolveURL(baseURL, value)
+			if resolved == "" || !IsValidHTTPURL(resolved) || seen[resolved] {
+				return
+			}
+
+			seen[resolved] = true
+			resources = append(resources, ResourceRef{URL: resolved, Type: rs.resourceType})
+		})
+	}
+
+	return resources
+}
+
+// collectReferences returns every URL referenced by a page and the resource type of each URL.
+// Anchors take precedence when the same URL is referenced in several ways.
+func collectReferences(parseResult *ParseResult) ([]string, map[string]string) {
+	var urls []string
+	types := make(map[string]string)
+
+	add := func(link, resourceType string) {
+		if _, exists := types[link]; exists {
+			return
+		}
+		types[link] = resourceType
+		urls = append(urls, link)
+	}
+
+	for _, link := range parseResult.InternalLinks {
+		add(link, ResourceLink)
+	}
+	for _, link := range parseResult.ExternalLinks {
+		add(link, ResourceLink)
+	}
+	for _, link := range ImageURLs(parseResult.Images) {
+		add(link, ResourceImage)
+	}
+	for _, resource := range parseResult.Resources {
+		add(resource.URL, resource.Type)
+	}
+
+	return urls, types
+}
@@ -0,0 +1,105 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExtractResources(t *testing.T) {
+	sampleHTML := `
+<html>
+<head>
+    <link rel="stylesheet" href="/css/site.css">
+    <link rel="preload" href="/fonts/font.woff2">
+    <link rel="shortcut icon" href="/favicon.ico">
+    <script src="/js/app.js"></script>
+    <script>inline()</script>
+</head>
+<body>
+    <iframe src="https://video.example.net/embed/1"></iframe>
+    <video poster="/poster.jpg"><source src="/movie.mp4" type="video/mp4"></video>
+    <audio src="/sound.mp3"></audio>
+    <object data="/doc.pdf"></object>
+    <script src="/js/app.js"></script>
+</body>
+</html>`
+
+	result, err := ParseHTML(strings.NewReader(sampleHTML), "https://example.com/")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	expected := map[string]string{
+		"https://example.com/css/site.css":  ResourceStylesheet,
+		"https://example.com/favicon.ico":   ResourceIcon,
+		"https://example.com/js/app.js":     ResourceScript,
+		"https://video.example.net/embed/1": ResourceIframe,
+		"https://example.com/poster.jpg":    ResourceMedia,
+		"https://example.com/movie.mp4":     ResourceMedia,
+		"https://example.com/sound.mp3":     ResourceMedia,
+		"https://example.com/doc.pdf":       ResourceObject,
+	}
+
+	if len(result.Resources) != len(expected) {
+		t.Errorf("Expected %d resources, got %d: %+v", len(expected), len(result.Resources), result.Resources)
+	}
+	for _, resource := range result.Resources {
+		if expected[resource.URL] != resource.Type {
+			t.Errorf("Expected %s to be %s, got %s", resource.URL, expected[resource.URL], resource.Type)
+		}
+	}
+}
+
+func TestCollectBrokenLinksResourceTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ok" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	parseResult := &ParseResult{
+		InternalLinks: []string{server.URL + "/ok", server.URL + "/missing-page"},
+		Images:        []ImageInfo{{URL: server.URL + "/missing.png"}},
+		Resources: []ResourceRef{
+			{URL: server.URL + "/missing.css", Type: ResourceStylesheet},
+			{URL: server.URL + "/missing-page", Type: ResourceIframe},
+		},
+	}
+
+	links, types := collectReferences(parseResult)
+	if len(links) != 4 {
+		t.Fatalf("Expected 4 distinct references, got %d", len(links))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	analyzer := NewLinkAnalyzer(nil, nil, nil)
+	brokenLinks := collectBrokenLinks(links, analyzer.CheckLinks(ctx, links), types)
+
+	got := make(map[string]string)
+	for _, broken := range brokenLinks {
+		got[strings.TrimPrefix(broken.URL, server.URL)] = broken.ResourceType
+	}
+
+	want := map[string]string{
+		"/missing-page": ResourceLink, // Anchors win over other references
+		"/missing.png":  ResourceImage,
+		"/missing.css":  ResourceStylesheet,
+	}
+	if len(got) != len(want) {
+		t.Errorf("Expected %d broken links, got %+v", len(want), got)
+	}
+	for path, resourceType := range want {
+		if got[path] != resourceType {
+			t.Errorf("Expected %s to be a broken %s, got '%s'", path, resourceType, got[path])
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package crawler
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Adaptive backoff tuning
+const (
+	minHostRate        = 0.2             // Slowest rate a throttled host is reduced to (requests per second)
+	slowResponseTime   = 2 * time.Second // Responses slower than this reduce the host's rate
+	hostRateRecovery   = 0.1             // Fraction of the base rate regained per fast response
+	throttledBackoff   = 0.5             // Rate multiplier after a 429 or 503
+	slowResponseFactor = 0.75            // Rate multiplier after a slow response
+	maxTrackedHosts    = 10000           // Healthy hosts are forgotten beyond this many
+)
+
+// HostScheduler spaces out requests with one token bucket per host. It is shared by all
+// running crawls, so requests to different hosts run concurrently while each host only
+// sees a polite request rate that adapts to throttling and slow responses.
+type HostScheduler struct {
+	mutex    sync.Mutex
+	baseRate float64 // Requests per second for a healthy host
+	burst    float64
+	hosts    map[string]*hostBucket
+	slots    chan struct{} // Bounds the requests in flight across all hosts
+	now      func() time.Time
+}
+
+// hostBucket is the token bucket and backoff state of a single host
+type hostBucket struct {
+	tokens       float64
+	rate         float64
+	lastRefill   time.Time
+	backoffUntil time.Time
+}
+
+// HostRateState describes the current scheduling state of a host
+type HostRateState struct {
+	Host         string    `json:"host"`
+	Rate         float64   `json:"rate"`
+	BackoffUntil time.Time `json:"backoff_until,omitempty"`
+}
+
+// NewHostScheduler creates a scheduler with the given per-host rate, burst and global in-flight limit
+func NewHostScheduler(requestsPerSecond float64, burst, maxInFlight int) *HostScheduler {
+	if requestsPerSecond <= 0 {
+		requestsPerSecond = 1
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	if maxInFlight < 1 {
+		maxInFlight = 1
+	}
+
+	return &HostScheduler{
+		baseRate: requestsPerSecond,
+		burst:    float64(burst),
+		hosts:    make(map[string]*hostBucket),
+		slots:    make(chan struct{}, maxInFlight),
+		now:      time.Now,
+	}
+}
+
+// Acquire waits until a request to the URL's host may be sent. The returned function
+// must be called once the response has been received.
+func (s *HostScheduler) Acquire(ctx context.Context, rawURL string) (func(), error) {
+	host := schedulerHost(rawURL)
+
+	for {
+		wait := s.reserve(host)
+		if wait <= 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case s.slots <- struct{}{}:
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-s.slots })
+	}, nil
+}
+
+// reserve takes a token for the host, or returns how long to wait for one
+func (s *HostScheduler) reserve(host string) time.Duration {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	bucket := s.bucket(host, now)
+
+	if now.Before(bucket.backoffUntil) {
+		return bucket.backoffUntil.Sub(now)
+	}
+
+	elapsed := now.Sub(bucket.lastRefill).Seconds()
+	bucket.tokens += elapsed * bucket.rate
+	if bucket.tokens > s.burst {
+		bucket.tokens = s.burst
+	}
+	bucket.lastRefill = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - bucket.tokens) / bucket.rate * float64(time.Second))
+}
+
+// Report adapts the host's rate to a response: throttling halves it and pauses the host
+// for retryAfter (at most maxRetryAfter), slow responses reduce it and fast successful responses slowly restore it.
+// A statusCode of 0 reports a request that failed without a response.
+func (s *HostScheduler) Report(rawURL string, statusCode int, elapsed, retryAfter time.Duration) {
+	host := schedulerHost(rawURL)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	bucket := s.bucket(host, now)
+
+	switch {
+	case statusCode == 429 || statusCode == 503:
+		bucket.rate *= throttledBackoff
+		if retryAfter <= 0 {
+			retryAfter = time.Duration(float64(time.Second) / bucket.rate)
+		}
+		// Long pauses would stall every crawl linking to the host; those links are reported as rate limited instead
+		if retryAfter > maxRetryAfter {
+			retryAfter = maxRetryAfter
+		}
+		if until := now.Add(retryAfter); until.After(bucket.backoffUntil) {
+			bucket.backoffUntil = until
+		}
+		bucket.tokens = 0
+	case elapsed > slowResponseTime:
+		bucket.rate *= slowResponseFactor
+	case statusCode > 0 && statusCode < 500:
+		bucket.rate += s.baseRate * hostRateRecovery
+	}
+
+	if bucket.rate < minHostRate {
+		bucket.rate = minHostRate
+	}
+	if bucket.rate > s.baseRate {
+		bucket.rate = s.baseRate
+	}
+}
+
+// HostStates returns the hosts that are currently slowed down or paused
+func (s *HostScheduler) HostStates() []HostRateState {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	states := []HostRateState{}
+	for host, bucket := range s.hosts {
+		if bucket.rate >= s.baseRate && !now.Before(bucket.backoffUntil) {
+			continue
+		}
+		state := HostRateState{Host: host, Rate: bucket.rate}
+		if now.Before(bucket.backoffUntil) {
+			state.BackoffUntil = bucket.backoffUntil
+		}
+		states = append(states, state)
+	}
+	return states
+}
+
+// bucket returns the host's bucket, creating a full one on first use
+func (s *HostScheduler) bucket(host string, now time.Time) *hostBucket {
+	bucket, exists := s.hosts[host]
+	if !exists {
+		if len(s.hosts) >= maxTrackedHosts {
+			s.pruneHealthyHosts(now)
+		}
+		bucket = &hostBucket{tokens: s.burst, rate: s.baseRate, lastRefill: now}
+		s.hosts[host] = bucket
+	}
+	return bucket
+}
+
+// pruneHealthyHosts forgets hosts that run at full rate and aren't paused
+func (s *HostScheduler) pruneHealthyHosts(now time.Time) {
+	for host, bucket := range s.hosts {
+		if bucket.rate >= s.baseRate && !now.Before(bucket.backoffUntil) {
+			delete(s.hosts, host)
+		}
+	}
+}
+
+// schedulerHost returns the host (with port) requests are scheduled by
+func schedulerHost(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return strings.ToLower(parsedURL.Host)
+}
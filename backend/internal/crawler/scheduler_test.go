@@ -0,0 +1,80 @@
+package crawler
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestHostSchedulerTokenBucket(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	scheduler := NewHostScheduler(2, 2, 10)
+	scheduler.now = func() time.Time { return now }
+
+	// The burst is available immediately, then tokens refill at the host's rate
+	for i := 0; i < 2; i++ {
+		if wait := scheduler.reserve("a.example"); wait != 0 {
+			t.Fatalf("Expected request %d to be sent immediately, got wait %v", i+1, wait)
+		}
+	}
+	if wait := scheduler.reserve("a.example"); wait != 500*time.Millisecond {
+		t.Errorf("Expected to wait 500ms for the next token, got %v", wait)
+	}
+
+	// Other hosts are not affected
+	if wait := scheduler.reserve("b.example"); wait != 0 {
+		t.Errorf("Expected another host to be scheduled immediately, got wait %v", wait)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if wait := scheduler.reserve("a.example"); wait != 0 {
+		t.Errorf("Expected a refilled token after 500ms, got wait %v", wait)
+	}
+}
+
+func TestHostSchedulerBackoff(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	scheduler := NewHostScheduler(4, 4, 10)
+	scheduler.now = func() time.Time { return now }
+
+	scheduler.Report("https://a.example/page", 429, 100*time.Millisecond, 10*time.Second)
+
+	if wait := scheduler.reserve("a.example"); wait != 10*time.Second {
+		t.Errorf("Expected the host to be paused for Retry-After, got wait %v", wait)
+	}
+
+	states := scheduler.HostStates()
+	if len(states) != 1 || states[0].Host != "a.example" || states[0].Rate != 2 {
+		t.Fatalf("Expected a.example at half rate, got %+v", states)
+	}
+
+	// Slow responses reduce the rate, fast successful ones restore it
+	scheduler.Report("https://a.example/page", 200, 5*time.Second, 0)
+	if rate := scheduler.hosts["a.example"].rate; rate != 1.5 {
+		t.Errorf("Expected rate 1.5 after a slow response, got %v", rate)
+	}
+	for i := 0; i < 20; i++ {
+		scheduler.Report("https://a.example/page", 200, 50*time.Millisecond, 0)
+	}
+	if rate := scheduler.hosts["a.example"].rate; rate != 4 {
+		t.Errorf("Expected rate to recover to 4, got %v", rate)
+	}
+}
+
+func TestHostSchedulerCancellation(t *testing.T) {
+	scheduler := NewHostScheduler(1, 1, 1)
+	scheduler.Report("https://a.example/", 429, 0, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := scheduler.Acquire(ctx, "https://a.example/"); err == nil {
+		t.Error("Expected Acquire to stop when the context is cancelled")
+	}
+
+	release, err := scheduler.Acquire(context.Background(), "https://b.example/")
+	if err != nil {
+		t.Fatalf("Expected another host to be available: %v", err)
+	}
+	release()
+}
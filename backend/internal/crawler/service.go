@@ -2,10 +2,14 @@ package crawler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/url"
+	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -14,31 +18,56 @@ import (
 
 // CrawlerConfig holds configuration for the crawler
 type CrawlerConfig struct {
-	Timeout         time.Duration
-	UserAgent       string
-	MaxRedirects    int
-	FollowRedirects bool
-	MaxRetries      int
-	RetryDelay      time.Duration
+	Timeout             time.Duration
+	UserAgent           string
+	MaxRedirects        int
+	FollowRedirects     bool
+	MaxRetries          int
+	RetryDelay          time.Duration
+	LinkCacheSize       int           // Maximum number of cached link check outcomes
+	LinkCacheSuccessTTL time.Duration // How long working links are trusted
+	LinkCacheFailureTTL time.Duration // How long failed links are trusted
+	HostRequestsPerSec  float64       // Request rate per host for link checking
+	HostBurst           int           // Requests a host may receive back to back
+	MaxInFlight         int           // Link check requests in flight across all crawls
+	BreakerThreshold    int           // Consecutive network failures that open a host's circuit breaker
+	BreakerCooldown     time.Duration // How long an open breaker short-circuits requests
+	TechnologyFile      string        // Optional JSON file extending the built-in technology signatures
 }
 
 // DefaultConfig returns a default crawler configuration
 func DefaultConfig() *CrawlerConfig {
 	return &CrawlerConfig{
-		Timeout:         30 * time.Second,
-		UserAgent:       "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/121.0.0.0 Safari/537.36",
-		MaxRedirects:    5,
-		FollowRedirects: true,
-		MaxRetries:      3,
-		RetryDelay:      2 * time.Second, // Increased delay to avoid rate limiting
+		Timeout:             30 * time.Second,
+		UserAgent:           "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/121.0.0.0 Safari/537.36",
+		MaxRedirects:        5,
+		FollowRedirects:     true,
+		MaxRetries:          3,
+		RetryDelay:          2 * time.Second, // Increased delay to avoid rate limiting
+		LinkCacheSize:       10000,
+		LinkCacheSuccessTTL: 6 * time.Hour,
+		LinkCacheFailureTTL: 15 * time.Minute, // Failures are often transient
+		HostRequestsPerSec:  4,
+		HostBurst:           4,
+		MaxInFlight:         32,
+		BreakerThreshold:    5,
+		BreakerCooldown:     time.Minute,
+		TechnologyFile:      os.Getenv("CRAWLER_TECHNOLOGY_SIGNATURES"),
 	}
 }
 
+// errFetchCancelled is returned by fetchPage when the context ends before a response arrives
+var errFetchCancelled = errors.New("fetch cancelled")
+
 // CrawlerService provides web crawling functionality
 type CrawlerService struct {
-	config *CrawlerConfig
-	client *http.Client
-	jobs   map[uint]context.CancelFunc // Track running jobs for cancellation
+	config    *CrawlerConfig
+	client    *http.Client
+	jobs      map[uint]context.CancelFunc // Track running jobs for cancellation
+	linkCache *LinkCache                  // Link check outcomes shared by all crawls
+	scheduler *HostScheduler              // Per-host request pacing shared by all crawls
+	breaker   *CircuitBreaker             // Per-host circuit breaker shared by all crawls
+	detector  *TechnologyDetector         // Technology signatures, nil when they failed to load
 }
 
 // NewCrawlerService creates a new crawler service with the given configuration
@@ -61,10 +90,46 @@ func NewCrawlerService(config *CrawlerConfig) *CrawlerService {
 		},
 	}
 
+	detector, err := NewTechnologyDetector(config.TechnologyFile)
+	if err != nil {
+		log.Printf("[CRAWLER] Failed to load technology signatures, using the built-in ones: %v", err)
+		detector, err = NewTechnologyDetector("")
+		if err != nil {
+			log.Printf("[CRAWLER] Technology detection disabled: %v", err)
+		}
+	}
+
 	return &CrawlerService{
-		config: config,
-		client: client,
-		jobs:   make(map[uint]context.CancelFunc),
+		config:    config,
+		client:    client,
+		jobs:      make(map[uint]context.CancelFunc),
+		linkCache: NewLinkCache(config.LinkCacheSize, config.LinkCacheSuccessTTL, config.LinkCacheFailureTTL),
+		scheduler: NewHostScheduler(config.HostRequestsPerSec, config.HostBurst, config.MaxInFlight),
+		breaker:   NewCircuitBreaker(config.BreakerThreshold, config.BreakerCooldown),
+		detector:  detector,
+	}
+}
+
+// WithRequestSettings returns a crawler service with its own timeout and retries that
+// shares the link cache, host scheduler and circuit breaker of this one, so that both
+// pace their requests to a host together
+func (c *CrawlerService) WithRequestSettings(timeout time.Duration, maxRetries int, retryDelay time.Duration) *CrawlerService {
+	config := *c.config
+	config.Timeout = timeout
+	config.MaxRetries = maxRetries
+	config.RetryDelay = retryDelay
+
+	client := *c.client
+	client.Timeout = timeout
+
+	return &CrawlerService{
+		config:    &config,
+		client:    &client,
+		jobs:      make(map[uint]context.CancelFunc),
+		linkCache: c.linkCache,
+		scheduler: c.scheduler,
+		breaker:   c.breaker,
+		detector:  c.detector,
 	}
 }
 
@@ -108,6 +173,7 @@ func (c *CrawlerService) ValidateURL(rawURL string) (*url.URL, error) {
 type CrawlResult struct {
 	URL           string
 	StatusCode    int
+	ResponseTime  time.Duration // Time until the response headers arrived
 	Title         string
 	HTMLVersion   string
 	InternalLinks int
@@ -115,20 +181,56 @@ type CrawlResult struct {
 	BrokenLinks   int
 	HasLoginForm  bool
 	HeadingCounts map[string]int
+	Outline       HeadingOutline
 	MetaTags      map[string]string
 	BrokenLinksDetails []BrokenLinkInfo
+	SkippedLinks  []SkippedLink
+	CanonicalURL  string
+	Hreflangs     []HreflangLink
+	MetaRobots    RobotsDirectives
+	XRobotsTag    RobotsDirectives
+	IndexingIssues []IndexingIssue
+	Images        []ImageInfo
+	ImageIssues   []ImageIssue
+	MixedContent  []MixedContentItem
+	Links         []LinkEdge
+	LinkIssues    []LinkIssue
+	Content       ContentMetrics
+	Technologies  []DetectedTechnology
+	Privacy       PrivacyReport
+	Forms         []FormInfo
+	FormIssues    []FormIssue
+	Analyzers     []AnalyzerResult  // Metrics and findings of the registered page analyzers
+	Extractions   []Extraction      // Values read by the user's extraction rules
+	Assertions    []AssertionResult // Outcome of the user's assertions about the page
+	ParseResult   *ParseResult // Full parse output for downstream audits
 	Error         string
 }
 
 // BrokenLinkInfo contains information about a broken link
 type BrokenLinkInfo struct {
-	URL        string
-	StatusCode int
-	Error      string
+	URL          string
+	StatusCode   int
+	Error        string
+	ResourceType string // Kind of reference, e.g. link, image, script or stylesheet
+	Class        string  // Outcome class, e.g. broken, blocked, rate_limited or timeout
+	SoftNotFound bool    // Page answered with success but looks like an error page
+	Confidence   float64 // Soft-404 confidence (0.0-1.0)
+}
+
+// CrawlOptions holds per-crawl settings chosen by the user
+type CrawlOptions struct {
+	DetectSoft404     bool             // Fetch internal link targets and flag pages that look like error pages
+	LinkFilter        *LinkFilter      // Include/exclude rules for link checking, nil for the defaults
+	CountedClasses    []string         // Link classes counted toward BrokenLinks, nil for the defaults
+	FreshLinkCheck    bool             // Check every link again instead of using cached outcomes
+	DisabledAnalyzers map[string]bool  // IDs of registered page analyzers not to run
+	ExtractionRules   []ExtractionRule // Values to read from the page, such as a price or a build version
+	Assertions        []Assertion      // Checks the page must pass, evaluated even when the crawl fails
 }
 
 // CrawlAsync starts an asynchronous crawl operation
-func (c *CrawlerService) CrawlAsync(ctx context.Context, urlID uint, targetURL string, resultCallback func(*CrawlResult)) error {
+func (c *CrawlerService) CrawlAsync(ctx context.Context, urlID uint, targetURL string, options *CrawlOptions, resultCallback func(*CrawlResult)) error {
 	// Validate URL first
 	parsedURL, err := c.ValidateURL(targetURL)
 	if err != nil {
@@ -149,7 +251,10 @@ func (c *CrawlerService) CrawlAsync(ctx context.Context, urlID uint, targetURL s
 			// Don't call cancel() here - that would cancel our own context!
 		}()
 
-		result := c.crawlURL(jobCtx, parsedURL.String())
+		result := c.crawlURL(jobCtx, parsedURL.String(), options)
+		if options != nil && jobCtx.Err() == nil {
+			result.Assertions = EvaluateAssertions(result, options.Assertions)
+		}
 		
 		// Always call the callback, even if there was an error or cancellation
 		// The callback needs to update the URL status regardless of success/failure
@@ -169,6 +274,50 @@ func (c *CrawlerService) StopCrawl(urlID uint) error {
 	return fmt.Errorf("no running crawl job found for URL ID %d", urlID)
 }
 
+// CrawlerMetrics describes the shared state of the crawler
+type CrawlerMetrics struct {
+	RunningJobs      int             `json:"running_jobs"`
+	LinkCacheEntries int             `json:"link_cache_entries"`
+	CircuitBreakers  []BreakerState  `json:"circuit_breakers"`
+	ThrottledHosts   []HostRateState `json:"throttled_hosts"`
+}
+
+// Metrics returns a snapshot of running jobs, the link cache, circuit breakers and throttled hosts
+func (c *CrawlerService) Metrics() CrawlerMetrics {
+	return CrawlerMetrics{
+		RunningJobs:      len(c.jobs),
+		LinkCacheEntries: c.linkCache.Len(),
+		CircuitBreakers:  c.breaker.States(),
+		ThrottledHosts:   c.scheduler.HostStates(),
+	}
+}
+
+// MetricsForURLs returns the metrics with circuit breakers and throttled hosts limited to
+// the hosts of the given URLs, so that users only see the hosts their own crawls touch
+func (c *CrawlerService) MetricsForURLs(urls []string) CrawlerMetrics {
+	hosts := make(map[string]bool, len(urls))
+	for _, rawURL := range urls {
+		hosts[schedulerHost(rawURL)] = true
+	}
+
+	metrics := c.Metrics()
+	breakers := []BreakerState{}
+	for _, state := range metrics.CircuitBreakers {
+		if hosts[state.Host] {
+			breakers = append(breakers, state)
+		}
+	}
+	throttled := []HostRateState{}
+	for _, state := range metrics.ThrottledHosts {
+		if hosts[state.Host] {
+			throttled = append(throttled, state)
+		}
+	}
+	metrics.CircuitBreakers = breakers
+	metrics.ThrottledHosts = throttled
+	return metrics
+}
+
 // IsRunning checks if a crawl is currently running for the given URL ID
 func (c *CrawlerService) IsRunning(urlID uint) bool {
 	_, exists := c.jobs[urlID]
@@ -176,8 +325,12 @@ func (c *CrawlerService) IsRunning(urlID uint) bool {
 }
 
 // crawlURL performs the actual crawling of a URL
-func (c *CrawlerService) crawlURL(ctx context.Context, targetURL string) *CrawlResult {
+func (c *CrawlerService) crawlURL(ctx context.Context, targetURL string, options *CrawlOptions) *CrawlResult {
 	log.Printf("[CRAWLER] Starting crawl for URL: %s", targetURL)
+
+	if options == nil {
+		options = &CrawlOptions{}
+	}
 	
 	result := &CrawlResult{
 		URL:           targetURL,
@@ -192,55 +345,24 @@ func (c *CrawlerService) crawlURL(ctx context.Context, targetURL string) *CrawlR
 		return result
 	}
 
-	// Create request with context
-	req, err := http.NewRequestWithContext(ctx, "GET", targetURL, nil)
-	if err != nil {
-		result.Error = fmt.Sprintf("Failed to create request: %v", err)
-		log.Printf("[CRAWLER] Failed to create request for URL %s: %v", targetURL, err)
-		return result
-	}
-
-	// Set browser-like headers to avoid bot detection
-	req.Header.Set("User-Agent", c.config.UserAgent)
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Connection", "keep-alive")
-	req.Header.Set("Cache-Control", "max-age=0")
-	
 	log.Printf("[CRAWLER] Making HTTP request to: %s", targetURL)
 
-	// Perform request with retries
-	var resp *http.Response
-	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
-		if ctx.Err() != nil {
-			result.Error = "Crawl was cancelled"
-			return result
-		}
-
-		resp, err = c.client.Do(req)
-		if err == nil {
-			break
-		}
-
-		if attempt < c.config.MaxRetries {
-			select {
-			case <-ctx.Done():
-				result.Error = "Crawl was cancelled"
-				return result
-			case <-time.After(c.config.RetryDelay):
-				continue
-			}
-		}
+	resp, responseTime, err := c.fetchPage(ctx, targetURL)
+	if err == errFetchCancelled {
+		result.Error = "Crawl was cancelled"
+		return result
 	}
-
 	if err != nil {
-		result.Error = fmt.Sprintf("Failed to fetch URL after %d attempts: %v", c.config.MaxRetries+1, err)
-		log.Printf("[CRAWLER] HTTP request failed for URL %s after %d attempts: %v", targetURL, c.config.MaxRetries+1, err)
+		result.Error = fmt.Sprintf("Failed to fetch URL: %v", err)
+		log.Printf("[CRAWLER] HTTP request failed for URL %s: %v", targetURL, err)
 		return result
 	}
 
 	defer resp.Body.Close()
 	result.StatusCode = resp.StatusCode
+	result.ResponseTime = responseTime
+	// Address of the page after redirects, which links and self-references are resolved against
+	pageURL := resp.Request.URL.String()
 	log.Printf("[CRAWLER] HTTP response for URL %s: status=%d", targetURL, resp.StatusCode)
 
 	// Check if response is HTML
@@ -254,7 +376,8 @@ func (c *CrawlerService) crawlURL(ctx context.Context, targetURL string) *CrawlR
 
 	// Parse HTML content
 	log.Printf("[CRAWLER] Parsing HTML content for URL: %s", targetURL)
-	parseResult, err := ParseHTML(resp.Body, targetURL)
+	rawHTML := &limitedBuffer{limit: maxTechnologyHTMLBytes}
+	parseResult, err := ParseHTML(io.TeeReader(resp.Body, rawHTML), pageURL)
 	if err != nil {
 		result.Error = fmt.Sprintf("Failed to parse HTML: %v", err)
 		log.Printf("[CRAWLER] HTML parsing failed for URL %s: %v", targetURL, err)
@@ -262,52 +385,242 @@ func (c *CrawlerService) crawlURL(ctx context.Context, targetURL string) *CrawlR
 	}
 
 	// Populate result with parsed data
+	result.ParseResult = parseResult
 	result.Title = parseResult.Title
 	result.HTMLVersion = parseResult.HTMLVersion
 	result.HeadingCounts = parseResult.HeadingCounts
+	result.Outline = parseResult.Outline
 	result.MetaTags = parseResult.MetaTags
 	result.HasLoginForm = parseResult.HasLoginForm
 	result.InternalLinks = len(parseResult.InternalLinks)
 	result.ExternalLinks = len(parseResult.ExternalLinks)
+	result.CanonicalURL = parseResult.CanonicalURL
+	result.Hreflangs = parseResult.Hreflangs
+	result.MetaRobots = parseResult.Robots
+	parseResult.XRobotsTag = ParseXRobotsTag(resp.Header)
+	result.XRobotsTag = parseResult.XRobotsTag
+	result.MixedContent = parseResult.MixedContent
+	result.Links = parseResult.Links
+	result.LinkIssues = parseResult.LinkIssues
+	result.Content = parseResult.Content
+	result.Privacy = parseResult.Privacy
+	result.Forms = parseResult.Forms
+	result.FormIssues = parseResult.FormIssues
+
+	// Fingerprint the software behind the page
+	var scripts []string
+	for _, resource := range parseResult.Resources {
+		if resource.Type == ResourceScript {
+			scripts = append(scripts, resource.URL)
+		}
+	}
+	result.Technologies = c.detector.Detect(TechnologyEvidence{
+		Headers: resp.Header,
+		Cookies: resp.Cookies(),
+		Meta:    parseResult.MetaNames,
+		Scripts: scripts,
+		HTML:    rawHTML.String(),
+	})
+	
+	// Run the registered page analyzers the user hasn't disabled
+	result.Analyzers = RunAnalyzers(&PageContext{
+		Context:  ctx,
+		URL:      resp.Request.URL,
+		Response: resp,
+		Document: parseResult.Document,
+		Parsed:   parseResult,
+	}, options.DisabledAnalyzers)
+
+	// Read the values the user asked for
+	result.Extractions = ExtractValues(parseResult.Document, resp.Request.URL.String(), options.ExtractionRules)
 	
 	log.Printf("[CRAWLER] HTML parsed successfully for URL %s: title='%s', internal=%d, external=%d", 
 		targetURL, result.Title, result.InternalLinks, result.ExternalLinks)
 
-	// Perform advanced link analysis with broken link detection
-	allLinks := append(parseResult.InternalLinks, parseResult.ExternalLinks...)
-	
-	// Deduplicate links before analysis
-	allLinks = DeduplicateLinks(allLinks)
+	// Perform advanced link analysis with broken link detection.
+	// Anchors, images and sub-resources are all checked in a single pass.
+	allLinks, resourceTypes := collectReferences(parseResult)
 	
 	// Create link analyzer and check for broken links
+	linkAnalyzer := NewLinkAnalyzer(c.config, c.scheduler, c.breaker)
+	if options.LinkFilter != nil {
+		linkAnalyzer.SetFilter(options.LinkFilter)
+	}
+	linkAnalyzer.UseCache(c.linkCache, options.FreshLinkCheck)
+	result.SkippedLinks = linkAnalyzer.SkippedLinks(allLinks)
+	checks := map[string]*LinkCheckResult{}
 	if len(allLinks) > 0 && ctx.Err() == nil {
-		linkAnalyzer := NewLinkAnalyzer(c.config)
-		result.BrokenLinksDetails = linkAnalyzer.AnalyzeLinks(ctx, allLinks)
-		result.BrokenLinks = len(result.BrokenLinksDetails)
+		checks = linkAnalyzer.CheckLinks(ctx, allLinks)
+		result.BrokenLinksDetails = collectBrokenLinks(allLinks, checks, resourceTypes)
 	} else {
-		result.BrokenLinks = 0
 		result.BrokenLinksDetails = []BrokenLinkInfo{}
 	}
 
+	// Validate #fragments against the ids of their target documents
+	if len(parseResult.Fragments) > 0 && ctx.Err() == nil {
+		danglingAnchors := c.validateFragments(ctx, parseResult, checks, linkAnalyzer.filter)
+		result.BrokenLinksDetails = append(result.BrokenLinksDetails, danglingAnchors...)
+	}
+
+	// Look for internal pages that answer with success but are really error pages
+	if options.DetectSoft404 && len(parseResult.InternalLinks) > 0 && ctx.Err() == nil {
+		softNotFound := c.detectSoft404s(ctx, targetURL, parseResult.InternalLinks, checks)
+		result.BrokenLinksDetails = append(result.BrokenLinksDetails, softNotFound...)
+	}
+
+	// Only the classes chosen by the user count as broken
+	result.BrokenLinks = CountBrokenLinks(result.BrokenLinksDetails, options.CountedClasses)
+
+	// Record image sizes and breakage
+	result.Images = parseResult.Images
+	result.ImageIssues = append([]ImageIssue{}, parseResult.ImageIssues...)
+	result.ImageIssues = append(result.ImageIssues, applyImageChecks(result.Images, checks)...)
+
+	// Check canonical, hreflang and robots directives
+	if ctx.Err() == nil {
+		robots := result.MetaRobots.Merge(result.XRobotsTag)
+		result.IndexingIssues = c.analyzeIndexing(ctx, pageURL, parseResult, robots, linkAnalyzer)
+	}
+
 	return result
 }
 
+// fetchPage requests a page with browser-like headers, retrying network failures. It returns
+// the response with the time its headers took to arrive; the caller must close the body.
+func (c *CrawlerService) fetchPage(ctx context.Context, targetURL string) (*http.Response, time.Duration, error) {
+	if ctx.Err() != nil {
+		return nil, 0, errFetchCancelled
+	}
+
+	// Create request with context
+	req, err := http.NewRequestWithContext(ctx, "GET", targetURL, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set browser-like headers to avoid bot detection
+	req.Header.Set("User-Agent", c.config.UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	req.Header.Set("Connection", "keep-alive")
+	req.Header.Set("Cache-Control", "max-age=0")
+
+	// Perform request with retries
+	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+		if ctx.Err() != nil {
+			return nil, 0, errFetchCancelled
+		}
+
+		started := time.Now()
+		var resp *http.Response
+		resp, err = c.client.Do(req)
+		if err == nil {
+			return resp, time.Since(started), nil
+		}
+
+		if attempt < c.config.MaxRetries {
+			select {
+			case <-ctx.Done():
+				return nil, 0, errFetchCancelled
+			case <-time.After(c.config.RetryDelay):
+				continue
+			}
+		}
+	}
+
+	return nil, 0, fmt.Errorf("no response after %d attempts: %w", c.config.MaxRetries+1, err)
+}
+
+// fetchAndParse downloads a related page (alternate, anchor target, ...) and parses it
+func (c *CrawlerService) fetchAndParse(ctx context.Context, pageURL string) (*ParseResult, error) {
+	resp, err := c.fetchRelated(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	parseResult, err := ParseHTML(resp.Body, resp.Request.URL.String())
+	if err != nil {
+		log.Printf("[CRAWLER] Failed to parse related page %s: %v", pageURL, err)
+		return nil, err
+	}
+
+	return parseResult, nil
+}
+
+// fetchRelated requests a page related to the crawled one, paced by the host scheduler
+func (c *CrawlerService) fetchRelated(ctx context.Context, pageURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.config.UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+	if !c.breaker.Allow(pageURL) {
+		return nil, fmt.Errorf("host unreachable (circuit breaker open)")
+	}
+
+	release, err := c.scheduler.Acquire(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	started := time.Now()
+	resp, err := c.client.Do(req)
+	if err != nil {
+		c.scheduler.Report(pageURL, 0, time.Since(started), 0)
+		if ctx.Err() == nil {
+			c.breaker.RecordFailure(pageURL)
+		}
+		return nil, err
+	}
+	c.breaker.RecordSuccess(pageURL)
+
+	retryAfter, _ := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	c.scheduler.Report(pageURL, resp.StatusCode, time.Since(started), retryAfter)
+
+	return resp, nil
+}
+
 // ConvertToAnalysisResult converts CrawlResult to database model
 func (c *CrawlerService) ConvertToAnalysisResult(crawlResult *CrawlResult, urlID uint) *models.AnalysisResult {
+	metaDescription := truncateRunes(crawlResult.MetaTags["description"], 1000)
+
 	analysis := &models.AnalysisResult{
-		URLID:         urlID,
-		Title:         crawlResult.Title,
-		HTMLVersion:   crawlResult.HTMLVersion,
-		InternalLinks: crawlResult.InternalLinks,
-		ExternalLinks: crawlResult.ExternalLinks,
-		BrokenLinks:   crawlResult.BrokenLinks,
-		HasLoginForm:  crawlResult.HasLoginForm,
-		H1Count:       crawlResult.HeadingCounts["h1"],
-		H2Count:       crawlResult.HeadingCounts["h2"],
-		H3Count:       crawlResult.HeadingCounts["h3"],
-		H4Count:       crawlResult.HeadingCounts["h4"],
-		H5Count:       crawlResult.HeadingCounts["h5"],
-		H6Count:       crawlResult.HeadingCounts["h6"],
+		URLID:                 urlID,
+		Title:                 crawlResult.Title,
+		HTMLVersion:           crawlResult.HTMLVersion,
+		InternalLinks:         crawlResult.InternalLinks,
+		ExternalLinks:         crawlResult.ExternalLinks,
+		BrokenLinks:           crawlResult.BrokenLinks,
+		HasLoginForm:          crawlResult.HasLoginForm,
+		H1Count:               crawlResult.HeadingCounts["h1"],
+		H2Count:               crawlResult.HeadingCounts["h2"],
+		H3Count:               crawlResult.HeadingCounts["h3"],
+		H4Count:               crawlResult.HeadingCounts["h4"],
+		H5Count:               crawlResult.HeadingCounts["h5"],
+		H6Count:               crawlResult.HeadingCounts["h6"],
+		CanonicalURL:          crawlResult.CanonicalURL,
+		RobotsMeta:            crawlResult.MetaRobots.String(),
+		XRobotsTag:            crawlResult.XRobotsTag.String(),
+		NoIndex:               crawlResult.MetaRobots.NoIndex || crawlResult.XRobotsTag.NoIndex,
+		NoFollow:              crawlResult.MetaRobots.NoFollow || crawlResult.XRobotsTag.NoFollow,
+		WordCount:             crawlResult.Content.WordCount,
+		SentenceCount:         crawlResult.Content.SentenceCount,
+		ReadingEase:           crawlResult.Content.ReadingEase,
+		TextHTMLRatio:         crawlResult.Content.TextHTMLRatio,
+		Language:              crawlResult.Content.Language,
+		ContentHash:           crawlResult.Content.Fingerprint,
+		MetaDescription:       metaDescription,
+		ConsentBanner:         crawlResult.Privacy.ConsentBanner,
+		ConsentProvider:       crawlResult.Privacy.ConsentProvider,
+		TrackersBeforeConsent: crawlResult.Privacy.TrackersBeforeConsent(),
 	}
 
 	// Set analyzed time
@@ -323,13 +636,352 @@ func (c *CrawlerService) ConvertToBrokenLinks(crawlResult *CrawlResult, analysis
 	
 	for _, linkInfo := range crawlResult.BrokenLinksDetails {
 		brokenLink := models.BrokenLink{
-			AnalysisID: analysisID,
-			URL:        linkInfo.URL,
-			StatusCode: linkInfo.StatusCode,
-			Error:      linkInfo.Error,
+			AnalysisID:   analysisID,
+			URL:          linkInfo.URL,
+			StatusCode:   linkInfo.StatusCode,
+			Error:        linkInfo.Error,
+			ResourceType: linkInfo.ResourceType,
+			Class:        linkInfo.Class,
+			SoftNotFound: linkInfo.SoftNotFound,
+			Confidence:   linkInfo.Confidence,
 		}
 		brokenLinks = append(brokenLinks, brokenLink)
 	}
 	
 	return brokenLinks
-} 
\ No newline at end of file
+}
+
+// ConvertToSkippedLinks converts links excluded by filter rules to database models
+func (c *CrawlerService) ConvertToSkippedLinks(crawlResult *CrawlResult, analysisID uint) []models.SkippedLink {
+	var skippedLinks []models.SkippedLink
+
+	for _, link := range crawlResult.SkippedLinks {
+		skippedLinks = append(skippedLinks, models.SkippedLink{
+			AnalysisID: analysisID,
+			URL:        link.URL,
+			Reason:     link.Reason,
+		})
+	}
+
+	return skippedLinks
+}
+
+// ConvertToLinkEdges converts the page's links to link graph edges
+func (c *CrawlerService) ConvertToLinkEdges(crawlResult *CrawlResult, analysisID, urlID uint) []models.LinkEdge {
+	var edges []models.LinkEdge
+
+	for _, link := range crawlResult.Links {
+		edges = append(edges, models.LinkEdge{
+			AnalysisID:    analysisID,
+			URLID:         urlID,
+			TargetURL:     link.URL,
+			AnchorText:    link.AnchorText,
+			Title:         link.Title,
+			Rel:           link.Rel,
+			NoFollow:      link.NoFollow,
+			Sponsored:     link.Sponsored,
+			UGC:           link.UGC,
+			Internal:      link.Internal,
+			Image:         link.Image,
+			BlankNoOpener: link.BlankNoOpener,
+		})
+	}
+
+	return edges
+}
+
+// ConvertToTechnologies converts the detected technologies to database models
+func (c *CrawlerService) ConvertToTechnologies(crawlResult *CrawlResult, analysisID uint) []models.Technology {
+	var technologies []models.Technology
+
+	for _, tech := range crawlResult.Technologies {
+		technologies = append(technologies, models.Technology{
+			AnalysisID: analysisID,
+			Name:       tech.Name,
+			Version:    tech.Version,
+			Categories: tech.Categories,
+			Website:    tech.Website,
+		})
+	}
+
+	return technologies
+}
+
+// ConvertToThirdParties converts the third-party inventory to database models
+func (c *CrawlerService) ConvertToThirdParties(crawlResult *CrawlResult, analysisID uint) []models.ThirdParty {
+	var thirdParties []models.ThirdParty
+
+	for _, party := range crawlResult.Privacy.ThirdParties {
+		thirdParties = append(thirdParties, models.ThirdParty{
+			AnalysisID:    analysisID,
+			Domain:        party.Domain,
+			Company:       party.Company,
+			Category:      party.Category,
+			Element:       party.Element,
+			URL:           party.URL,
+			Count:         party.Count,
+			BeforeConsent: party.BeforeConsent,
+		})
+	}
+
+	return thirdParties
+}
+
+// ConvertToForms converts the form inventory and form issues to database models
+func (c *CrawlerService) ConvertToForms(crawlResult *CrawlResult, analysisID uint) ([]models.Form, []models.FormIssue) {
+	var forms []models.Form
+	var issues []models.FormIssue
+
+	for _, form := range crawlResult.Forms {
+		fields := make([]models.FormField, 0, len(form.Fields))
+		for _, field := range form.Fields {
+			fields = append(fields, models.FormField{
+				Name:         field.Name,
+				Type:         field.Type,
+				Autocomplete: field.Autocomplete,
+				Required:     field.Required,
+			})
+		}
+
+		forms = append(forms, models.Form{
+			AnalysisID:   analysisID,
+			Position:     form.Position,
+			FormID:       form.ID,
+			Method:       form.Method,
+			Action:       form.Action,
+			Secure:       form.Secure,
+			CrossOrigin:  form.CrossOrigin,
+			Type:         form.Type,
+			HasPassword:  form.HasPassword,
+			HasCSRFToken: form.HasCSRFToken,
+			Fields:       fields,
+		})
+	}
+
+	for _, issue := range crawlResult.FormIssues {
+		issues = append(issues, models.FormIssue{
+			AnalysisID: analysisID,
+			Type:       issue.Type,
+			Position:   issue.Position,
+			URL:        issue.URL,
+			Message:    issue.Message,
+		})
+	}
+
+	return forms, issues
+}
+
+// ConvertToAnalyzerResults converts the page analyzer results to database models.
+// A failed analyzer is stored as an error finding.
+func (c *CrawlerService) ConvertToAnalyzerResults(crawlResult *CrawlResult, analysisID uint) ([]models.AnalyzerMetric, []models.AnalyzerFinding) {
+	var metrics []models.AnalyzerMetric
+	var findings []models.AnalyzerFinding
+
+	for _, result := range crawlResult.Analyzers {
+		names := make([]string, 0, len(result.Metrics))
+		for name := range result.Metrics {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			metrics = append(metrics, models.AnalyzerMetric{
+				AnalysisID: analysisID,
+				AnalyzerID: result.AnalyzerID,
+				Name:       name,
+				Value:      result.Metrics[name],
+			})
+		}
+
+		if result.Error != "" {
+			findings = append(findings, models.AnalyzerFinding{
+				AnalysisID: analysisID,
+				AnalyzerID: result.AnalyzerID,
+				Severity:   FindingError,
+				Message:    "Analyzer failed: " + result.Error,
+			})
+		}
+		for _, finding := range result.Findings {
+			findings = append(findings, models.AnalyzerFinding{
+				AnalysisID: analysisID,
+				AnalyzerID: result.AnalyzerID,
+				Severity:   finding.Severity,
+				Message:    finding.Message,
+			})
+		}
+	}
+
+	return metrics, findings
+}
+
+// ConvertToExtractions converts the extracted values to database models
+func (c *CrawlerService) ConvertToExtractions(crawlResult *CrawlResult, analysisID uint) []models.Extraction {
+	var extractions []models.Extraction
+
+	for _, extraction := range crawlResult.Extractions {
+		extractions = append(extractions, models.Extraction{
+			AnalysisID: analysisID,
+			RuleID:     extraction.RuleID,
+			Name:       extraction.Name,
+			Mode:       extraction.Mode,
+			Values:     extraction.Values,
+			Found:      len(extraction.Values) > 0,
+		})
+	}
+
+	return extractions
+}
+
+// ConvertToAssertionRun converts assertion outcomes to a database run with its results
+func (c *CrawlerService) ConvertToAssertionRun(crawlResult *CrawlResult, urlID uint) *models.AssertionRun {
+	run := &models.AssertionRun{
+		URLID:          urlID,
+		Passed:         true,
+		Total:          len(crawlResult.Assertions),
+		StatusCode:     crawlResult.StatusCode,
+		ResponseTimeMs: crawlResult.ResponseTime.Milliseconds(),
+	}
+
+	for _, assertion := range crawlResult.Assertions {
+		if !assertion.Passed {
+			run.Passed = false
+			run.Failed++
+		}
+		run.Results = append(run.Results, models.AssertionResult{
+			AssertionID: assertion.AssertionID,
+			Type:        assertion.Type,
+			Expected:    assertion.Expected,
+			Actual:      assertion.Actual,
+			Passed:      assertion.Passed,
+			Message:     assertion.Message,
+		})
+	}
+
+	return run
+}
+
+// ConvertToLinkIssues converts link issues to database models
+func (c *CrawlerService) ConvertToLinkIssues(crawlResult *CrawlResult, analysisID uint) []models.LinkIssue {
+	var issues []models.LinkIssue
+
+	for _, issue := range crawlResult.LinkIssues {
+		issues = append(issues, models.LinkIssue{
+			AnalysisID: analysisID,
+			Type:       issue.Type,
+			URL:        issue.URL,
+			AnchorText: issue.AnchorText,
+			Message:    issue.Message,
+		})
+	}
+
+	return issues
+}
+
+// ConvertToHreflangLinks converts hreflang links to database models
+func (c *CrawlerService) ConvertToHreflangLinks(crawlResult *CrawlResult, analysisID uint) []models.HreflangLink {
+	var hreflangLinks []models.HreflangLink
+
+	for _, link := range crawlResult.Hreflangs {
+		hreflangLinks = append(hreflangLinks, models.HreflangLink{
+			AnalysisID: analysisID,
+			Lang:       link.Lang,
+			URL:        link.URL,
+		})
+	}
+
+	return hreflangLinks
+}
+
+// ConvertToIndexingIssues converts indexing issues to database models
+func (c *CrawlerService) ConvertToIndexingIssues(crawlResult *CrawlResult, analysisID uint) []models.IndexingIssue {
+	var issues []models.IndexingIssue
+
+	for _, issue := range crawlResult.IndexingIssues {
+		issues = append(issues, models.IndexingIssue{
+			AnalysisID: analysisID,
+			Type:       issue.Type,
+			URL:        issue.URL,
+			Message:    issue.Message,
+		})
+	}
+
+	return issues
+}
+
+// ConvertToHeadings converts the heading outline to database models
+func (c *CrawlerService) ConvertToHeadings(crawlResult *CrawlResult, analysisID uint) ([]models.Heading, []models.HeadingIssue) {
+	var headings []models.Heading
+	var issues []models.HeadingIssue
+
+	for _, heading := range crawlResult.Outline.Headings {
+		headings = append(headings, models.Heading{
+			AnalysisID:     analysisID,
+			Position:       heading.Position,
+			Level:          heading.Level,
+			Text:           heading.Text,
+			Depth:          heading.Depth,
+			ParentPosition: heading.Parent,
+		})
+	}
+
+	for _, issue := range crawlResult.Outline.Issues {
+		issues = append(issues, models.HeadingIssue{
+			AnalysisID: analysisID,
+			Type:       issue.Type,
+			Position:   issue.Position,
+			Message:    issue.Message,
+		})
+	}
+
+	return headings, issues
+}
+
+// ConvertToImages converts the image inventory and image issues to database models
+func (c *CrawlerService) ConvertToImages(crawlResult *CrawlResult, analysisID uint) ([]models.Image, []models.ImageIssue) {
+	var images []models.Image
+	var issues []models.ImageIssue
+
+	for _, image := range crawlResult.Images {
+		images = append(images, models.Image{
+			AnalysisID:    analysisID,
+			URL:           image.URL,
+			Element:       image.Element,
+			Descriptor:    image.Descriptor,
+			Alt:           image.Alt,
+			HasAlt:        image.HasAlt,
+			Width:         image.Width,
+			Height:        image.Height,
+			Lazy:          image.Lazy,
+			StatusCode:    image.StatusCode,
+			ContentLength: image.Size,
+			Broken:        image.Broken,
+		})
+	}
+
+	for _, issue := range crawlResult.ImageIssues {
+		issues = append(issues, models.ImageIssue{
+			AnalysisID: analysisID,
+			Type:       issue.Type,
+			URL:        issue.URL,
+			Message:    issue.Message,
+		})
+	}
+
+	return images, issues
+}
+
+// ConvertToMixedContent converts mixed content findings to database models
+func (c *CrawlerService) ConvertToMixedContent(crawlResult *CrawlResult, analysisID uint) []models.MixedContent {
+	var items []models.MixedContent
+
+	for _, item := range crawlResult.MixedContent {
+		items = append(items, models.MixedContent{
+			AnalysisID: analysisID,
+			URL:        item.URL,
+			Element:    item.Element,
+			Attribute:  item.Attribute,
+			Type:       item.Type,
+		})
+	}
+
+	return items
+}
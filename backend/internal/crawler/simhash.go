@@ -0,0 +1,60 @@
+package crawler
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+)
+
+// simHashShingleSize is the number of consecutive words hashed together
+const simHashShingleSize = 2
+
+// MinFingerprintWords is the fewest words a page needs for a meaningful fingerprint
+const MinFingerprintWords = 20
+
+// SimHash computes a 64-bit SimHash fingerprint of the words, using overlapping word
+// shingles so that similar texts get fingerprints that differ in only a few bits.
+// It returns 0 for texts shorter than MinFingerprintWords.
+func SimHash(words []string) uint64 {
+	normalized := make([]string, 0, len(words))
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}))
+		if word != "" {
+			normalized = append(normalized, word)
+		}
+	}
+	if len(normalized) < MinFingerprintWords {
+		return 0
+	}
+
+	var weights [64]int
+	for i := 0; i+simHashShingleSize <= len(normalized); i++ {
+		hasher := fnv.New64a()
+		hasher.Write([]byte(strings.Join(normalized[i:i+simHashShingleSize], " ")))
+		hash := hasher.Sum64()
+
+		for bit := 0; bit < 64; bit++ {
+			if hash&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for bit := 0; bit < 64; bit++ {
+		if weights[bit] > 0 {
+			fingerprint |= 1 << bit
+		}
+	}
+	return fingerprint
+}
+
+// SimHashSimilarity returns the share of equal bits of two fingerprints, from 0 to 1
+func SimHashSimilarity(a, b uint64) float64 {
+	return 1 - float64(bits.OnesCount64(a^b))/64
+}
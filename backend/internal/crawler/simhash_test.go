@@ -0,0 +1,35 @@
+package crawler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSimHash(t *testing.T) {
+	base := "Our hiking boots are made from full grain leather with a waterproof membrane, " +
+		"a cushioned midsole and a grippy rubber outsole that handles wet rock, mud and snow. " +
+		"Every pair is resoled by hand in our workshop and comes with a two year warranty."
+	variant := strings.Replace(base, "two year warranty", "three year warranty", 1)
+	other := "The city council approved the new budget on Tuesday after a long debate about " +
+		"public transport, school funding and the renovation of the central library, which " +
+		"has been closed for repairs since the storm damaged its roof last winter."
+
+	baseHash := SimHash(strings.Fields(base))
+	if baseHash == 0 {
+		t.Fatal("Expected a fingerprint for a long text")
+	}
+
+	if similarity := SimHashSimilarity(baseHash, SimHash(strings.Fields(strings.ToUpper(base)))); similarity != 1 {
+		t.Errorf("Expected case to be ignored, got similarity %.2f", similarity)
+	}
+	if similarity := SimHashSimilarity(baseHash, SimHash(strings.Fields(variant))); similarity < 0.85 {
+		t.Errorf("Expected near-duplicate texts to be similar, got %.2f", similarity)
+	}
+	if similarity := SimHashSimilarity(baseHash, SimHash(strings.Fields(other))); similarity > 0.75 {
+		t.Errorf("Expected unrelated texts to differ, got %.2f", similarity)
+	}
+
+	if hash := SimHash(strings.Fields("Too short to fingerprint")); hash != 0 {
+		t.Errorf("Expected 0 for short texts, got %d", hash)
+	}
+}
@@ -0,0 +1,218 @@
+package crawler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Soft-404 detection limits
+const (
+	maxSoft404Checks     = 100
+	maxSoft404BodyBytes  = 1 << 20
+	Soft404MinConfidence = 0.6
+)
+
+// soft404Phrases are typical error page texts found in titles and headings
+var soft404Phrases = []string{
+	"not found", "404", "page doesn't exist", "page does not exist", "no longer available",
+	"page cannot be found", "page can't be found", "nothing was found", "oops",
+}
+
+// pageFingerprint summarizes a page for soft-404 comparison
+type pageFingerprint struct {
+	URL        string // Requested address
+	FinalURL   string // Address after redirects
+	StatusCode int
+	Title      string
+	Heading    string
+	Words      map[string]bool
+}
+
+// soft404Detector compares pages of a host with the response to a URL that cannot exist
+type soft404Detector struct {
+	baseline *pageFingerprint // Response for a random nonexistent URL, nil when the host returns real 404s
+}
+
+// detectSoft404s fetches internal links that passed the link check and flags the ones that look like error pages
+func (c *CrawlerService) detectSoft404s(ctx context.Context, pageURL string, internalLinks []string, checks map[string]*LinkCheckResult) []BrokenLinkInfo {
+	detector, err := c.newSoft404Detector(ctx, pageURL)
+	if err != nil {
+		log.Printf("[CRAWLER] Soft-404 fingerprinting failed for %s: %v", pageURL, err)
+		return nil
+	}
+
+	var candidates []string
+	for _, link := range internalLinks {
+		// The page nonexistent URLs redirect to (e.g. the homepage) is a real page
+		if detector.baseline != nil && link == detector.baseline.FinalURL {
+			continue
+		}
+		if check, exists := checks[link]; exists && !check.IsBroken() {
+			candidates = append(candidates, link)
+		}
+		if len(candidates) >= maxSoft404Checks {
+			break
+		}
+	}
+
+	var softNotFound []BrokenLinkInfo
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	// Fetches go through the host scheduler, which limits concurrency per host
+	for _, link := range candidates {
+		wg.Add(1)
+		go func(link string) {
+			defer wg.Done()
+
+			fingerprint, err := c.fetchFingerprint(ctx, link)
+			if err != nil || fingerprint.StatusCode >= 400 {
+				return
+			}
+
+			if confidence := detector.confidence(fingerprint); confidence >= Soft404MinConfidence {
+				mutex.Lock()
+				softNotFound = append(softNotFound, BrokenLinkInfo{
+					URL:          link,
+					StatusCode:   fingerprint.StatusCode,
+					Error:        fmt.Sprintf("Soft 404: page looks like an error page (confidence %.0f%%)", confidence*100),
+					ResourceType: ResourceLink,
+					Class:        LinkClassBroken,
+					SoftNotFound: true,
+					Confidence:   confidence,
+				})
+				mutex.Unlock()
+			}
+		}(link)
+	}
+
+	wg.Wait()
+	return softNotFound
+}
+
+// newSoft404Detector learns the host's response to a deliberately random nonexistent URL
+func (c *CrawlerService) newSoft404Detector(ctx context.Context, pageURL string) (*soft404Detector, error) {
+	parsedURL, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+	probeURL := fmt.Sprintf("%s://%s/%s-not-found", parsedURL.Scheme, parsedURL.Host, hex.EncodeToString(token))
+
+	fingerprint, err := c.fetchFingerprint(ctx, probeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	detector := &soft404Detector{}
+	// Only hosts answering a nonexistent URL with success have a soft-404 fingerprint
+	if fingerprint.StatusCode < 400 {
+		detector.baseline = fingerprint
+	}
+
+	return detector, nil
+}
+
+// confidence estimates how likely it is that a page is an error page (0.0-1.0)
+func (d *soft404Detector) confidence(page *pageFingerprint) float64 {
+	var confidence float64
+
+	if d.baseline != nil {
+		// Redirected to the same place as the nonexistent URL (e.g. the homepage)
+		if page.FinalURL != page.URL && page.FinalURL == d.baseline.FinalURL {
+			confidence += 0.7
+		}
+
+		if page.Title != "" && page.Title == d.baseline.Title {
+			confidence += 0.3
+		}
+
+		confidence += 0.5 * jaccardSimilarity(page.Words, d.baseline.Words)
+	}
+
+	// Error wording in the title and main heading
+	if containsSoft404Phrase(page.Title) {
+		confidence += 0.4
+	}
+	if containsSoft404Phrase(page.Heading) {
+		confidence += 0.3
+	}
+
+	if confidence > 1.0 {
+		confidence = 1.0
+	}
+	return confidence
+}
+
+// fetchFingerprint downloads a page and builds its fingerprint
+func (c *CrawlerService) fetchFingerprint(ctx context.Context, pageURL string) (*pageFingerprint, error) {
+	resp, err := c.fetchRelated(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	fingerprint := &pageFingerprint{
+		URL:        pageURL,
+		FinalURL:   resp.Request.URL.String(),
+		StatusCode: resp.StatusCode,
+		Words:      make(map[string]bool),
+	}
+
+	doc, err := goquery.NewDocumentFromReader(io.LimitReader(resp.Body, maxSoft404BodyBytes))
+	if err != nil {
+		return fingerprint, nil
+	}
+
+	fingerprint.Title = strings.ToLower(SanitizeText(doc.Find("title").First().Text()))
+	fingerprint.Heading = strings.ToLower(SanitizeText(doc.Find("h1").First().Text()))
+
+	body := doc.Find("body").Clone()
+	body.Find("script, style, noscript, template").Remove()
+	for _, word := range strings.Fields(strings.ToLower(body.Text())) {
+		fingerprint.Words[word] = true
+	}
+
+	return fingerprint, nil
+}
+
+// containsSoft404Phrase checks text for typical error page wording
+func containsSoft404Phrase(text string) bool {
+	text = strings.ToLower(text)
+	for _, phrase := range soft404Phrases {
+		if strings.Contains(text, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// jaccardSimilarity returns the overlap of two word sets (0.0-1.0)
+func jaccardSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+
+	intersection := 0
+	for word := range a {
+		if b[word] {
+			intersection++
+		}
+	}
+
+	union := len(a) + len(b) - intersection
+	return float64(intersection) / float64(union)
+}
@@ -0,0 +1,133 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDetectSoft404s(t *testing.T) {
+	notFoundPage := `<html><head><title>Example Shop</title></head><body><h1>Sorry</h1><p>We could not find what you were looking for. Try the search.</p></body></html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/products":
+			w.Write([]byte(`<html><head><title>Products</title></head><body><h1>Products</h1><p>Our full catalogue of garden tools and outdoor furniture.</p></body></html>`))
+		case "/discontinued":
+			w.Write([]byte(`<html><head><title>Page not found</title></head><body><h1>Not found</h1></body></html>`))
+		case "/home":
+			w.Write([]byte(notFoundPage))
+		default:
+			// Unknown paths answer with success instead of a 404
+			w.Write([]byte(notFoundPage))
+		}
+	}))
+	defer server.Close()
+
+	links := []string{server.URL + "/products", server.URL + "/discontinued", server.URL + "/old-offer", server.URL + "/broken"}
+	checks := map[string]*LinkCheckResult{
+		server.URL + "/products":     {StatusCode: 200},
+		server.URL + "/discontinued": {StatusCode: 200},
+		server.URL + "/old-offer":    {StatusCode: 200},
+		server.URL + "/broken":       {StatusCode: 404, Error: "HTTP 404"},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	crawler := NewCrawlerService(nil)
+	flagged := crawler.detectSoft404s(ctx, server.URL+"/", links, checks)
+
+	got := make(map[string]BrokenLinkInfo)
+	for _, broken := range flagged {
+		if !broken.SoftNotFound {
+			t.Errorf("Expected %s to be marked as soft 404", broken.URL)
+		}
+		if broken.Confidence < Soft404MinConfidence || broken.Confidence > 1.0 {
+			t.Errorf("Unexpected confidence %.2f for %s", broken.Confidence, broken.URL)
+		}
+		got[strings.TrimPrefix(broken.URL, server.URL)] = broken
+	}
+
+	for _, path := range []string{"/discontinued", "/old-offer"} {
+		if _, exists := got[path]; !exists {
+			t.Errorf("Expected %s to be flagged as soft 404", path)
+		}
+	}
+	for _, path := range []string{"/products", "/broken"} {
+		if _, exists := got[path]; exists {
+			t.Errorf("Did not expect %s to be flagged as soft 404", path)
+		}
+	}
+}
+
+func TestDetectSoft404sRedirectingToHome(t *testing.T) {
+	homePage := `<html><head><title>Example Shop</title></head><body><h1>Welcome</h1><p>Garden tools and outdoor furniture.</p></body></html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/":
+			w.Write([]byte(homePage))
+		case "/about":
+			w.Write([]byte(`<html><head><title>About us</title></head><body><h1>About us</h1><p>A family business since 1982.</p></body></html>`))
+		default:
+			// Unknown paths redirect to the homepage
+			http.Redirect(w, r, "/", http.StatusFound)
+		}
+	}))
+	defer server.Close()
+
+	links := []string{server.URL + "/", server.URL + "/about", server.URL + "/old-offer"}
+	checks := map[string]*LinkCheckResult{
+		server.URL + "/":          {StatusCode: 200},
+		server.URL + "/about":     {StatusCode: 200},
+		server.URL + "/old-offer": {StatusCode: 200},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	crawler := NewCrawlerService(nil)
+	flagged := crawler.detectSoft404s(ctx, server.URL+"/about", links, checks)
+
+	got := make(map[string]bool)
+	for _, broken := range flagged {
+		got[strings.TrimPrefix(broken.URL, server.URL)] = true
+	}
+
+	if !got["/old-offer"] {
+		t.Error("Expected /old-offer, which redirects to the homepage, to be flagged as soft 404")
+	}
+	for _, path := range []string{"/", "/about"} {
+		if got[path] {
+			t.Errorf("Did not expect %s to be flagged as soft 404", path)
+		}
+	}
+
+	// A link straight to the homepage is not redirected
+	detector := &soft404Detector{baseline: &pageFingerprint{FinalURL: server.URL + "/"}}
+	home := &pageFingerprint{URL: server.URL + "/", FinalURL: server.URL + "/"}
+	if confidence := detector.confidence(home); confidence != 0 {
+		t.Errorf("Expected confidence 0 for the homepage itself, got %.2f", confidence)
+	}
+}
+
+func TestSoft404ConfidenceWithRealNotFound(t *testing.T) {
+	// Hosts that return real 404s only get flagged on error wording
+	detector := &soft404Detector{}
+
+	page := &pageFingerprint{Title: "404 - page not found"}
+	if confidence := detector.confidence(page); confidence >= Soft404MinConfidence {
+		t.Errorf("Expected wording alone to stay below the threshold, got %.2f", confidence)
+	}
+
+	page = &pageFingerprint{Title: "about us"}
+	if confidence := detector.confidence(page); confidence != 0 {
+		t.Errorf("Expected confidence 0 for a normal page, got %.2f", confidence)
+	}
+}
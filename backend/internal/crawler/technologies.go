@@ -0,0 +1,360 @@
+package crawler
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// maxTechnologyHTMLBytes limits how much of the page HTML is matched against HTML signatures
+const maxTechnologyHTMLBytes = 512 * 1024
+
+// versionReference matches `\1`-style capture group references in version templates
+var versionReference = regexp.MustCompile(`\\(\d)`)
+
+// defaultTechnologySignatures is the built-in signature set, in a Wappalyzer-like format
+//
+//go:embed signatures/technologies.json
+var defaultTechnologySignatures []byte
+
+// TechnologySignature describes how to recognize a technology. Patterns are case-insensitive
+// regular expressions; an empty pattern only requires the header, meta tag or cookie to exist.
+// A pattern may end in `\;version:\1` to take the version from a capture group.
+type TechnologySignature struct {
+	Categories []string          `json:"categories"`
+	Website    string            `json:"website"`
+	Headers    map[string]string `json:"headers"`
+	Meta       map[string]string `json:"meta"`
+	Scripts    []string          `json:"scripts"`
+	Cookies    map[string]string `json:"cookies"`
+	HTML       []string          `json:"html"`
+	Implies    []string          `json:"implies"` // Technologies always used along with this one
+}
+
+// DetectedTechnology is a technology found on a page
+type DetectedTechnology struct {
+	Name       string
+	Categories []string
+	Version    string
+	Website    string
+}
+
+// TechnologyEvidence is what a page reveals about the software behind it
+type TechnologyEvidence struct {
+	Headers http.Header
+	Cookies []*http.Cookie
+	Meta    map[string]string // Lowercase meta names and their content
+	Scripts []string          // Script URLs
+	HTML    string
+}
+
+// technologyPattern is a compiled signature pattern
+type technologyPattern struct {
+	regex   *regexp.Regexp
+	version string // Version template such as `\1`, empty when the pattern carries no version
+}
+
+// technology is a compiled signature
+type technology struct {
+	name      string
+	signature TechnologySignature
+	headers   map[string][]technologyPattern
+	meta      map[string][]technologyPattern
+	cookies   map[string][]technologyPattern
+	scripts   []technologyPattern
+	html      []technologyPattern
+}
+
+// TechnologyDetector recognizes technologies from compiled signatures
+type TechnologyDetector struct {
+	technologies []*technology
+	byName       map[string]*technology
+}
+
+// NewTechnologyDetector compiles the built-in signatures, extended or overridden by name
+// with the signatures of extraFile when it is set
+func NewTechnologyDetector(extraFile string) (*TechnologyDetector, error) {
+	signatures, err := ParseTechnologySignatures(defaultTechnologySignatures)
+	if err != nil {
+		return nil, fmt.Errorf("invalid built-in technology signatures: %w", err)
+	}
+
+	if extraFile != "" {
+		data, err := os.ReadFile(extraFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read technology signatures: %w", err)
+		}
+		extra, err := ParseTechnologySignatures(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid technology signatures in %s: %w", extraFile, err)
+		}
+		for name, signature := range extra {
+			signatures[name] = signature
+		}
+	}
+
+	return newTechnologyDetector(signatures)
+}
+
+// ParseTechnologySignatures parses a JSON object of technology names to signatures
+func ParseTechnologySignatures(data []byte) (map[string]TechnologySignature, error) {
+	var signatures map[string]TechnologySignature
+	if err := json.Unmarshal(data, &signatures); err != nil {
+		return nil, err
+	}
+	return signatures, nil
+}
+
+// newTechnologyDetector compiles the signatures
+func newTechnologyDetector(signatures map[string]TechnologySignature) (*TechnologyDetector, error) {
+	detector := &TechnologyDetector{byName: make(map[string]*technology)}
+
+	names := make([]string, 0, len(signatures))
+	for name := range signatures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		signature := signatures[name]
+		tech := &technology{name: name, signature: signature}
+
+		var err error
+		if tech.headers, err = compilePatternMap(signature.Headers, true); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if tech.meta, err = compilePatternMap(signature.Meta, true); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if tech.cookies, err = compilePatternMap(signature.Cookies, false); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if tech.scripts, err = compilePatterns(signature.Scripts); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if tech.html, err = compilePatterns(signature.HTML); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		detector.technologies = append(detector.technologies, tech)
+		detector.byName[name] = tech
+	}
+
+	return detector, nil
+}
+
+// Detect returns the technologies found in the evidence, including implied ones, sorted by name
+func (d *TechnologyDetector) Detect(evidence TechnologyEvidence) []DetectedTechnology {
+	if d == nil {
+		return nil
+	}
+
+	html := evidence.HTML
+	if len(html) > maxTechnologyHTMLBytes {
+		html = html[:maxTechnologyHTMLBytes]
+	}
+
+	cookies := make(map[string]string)
+	for _, cookie := range evidence.Cookies {
+		cookies[cookie.Name] = cookie.Value
+	}
+
+	found := make(map[string]string) // Name to version
+	for _, tech := range d.technologies {
+		matched := false
+		version := ""
+		record := func(ok bool, v string) {
+			if ok {
+				matched = true
+				if len(v) > len(version) {
+					version = v
+				}
+			}
+		}
+
+		for header, patterns := range tech.headers {
+			if values, exists := evidence.Headers[http.CanonicalHeaderKey(header)]; exists {
+				for _, value := range values {
+					record(matchAny(patterns, value))
+				}
+			}
+		}
+		for name, patterns := range tech.meta {
+			if content, exists := evidence.Meta[name]; exists {
+				record(matchAny(patterns, content))
+			}
+		}
+		for name, patterns := range tech.cookies {
+			if value, exists := cookies[name]; exists {
+				record(matchAny(patterns, value))
+			}
+		}
+		for _, script := range evidence.Scripts {
+			record(matchAny(tech.scripts, script))
+		}
+		if html != "" {
+			record(matchAny(tech.html, html))
+		}
+
+		if matched {
+			found[tech.name] = version
+		}
+	}
+
+	// Add implied technologies, following chains such as WooCommerce -> WordPress -> PHP
+	queue := make([]string, 0, len(found))
+	for name := range found {
+		queue = append(queue, name)
+	}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, implied := range d.byName[name].signature.Implies {
+			if _, exists := found[implied]; exists {
+				continue
+			}
+			if _, known := d.byName[implied]; !known {
+				continue
+			}
+			found[implied] = ""
+			queue = append(queue, implied)
+		}
+	}
+
+	detected := make([]DetectedTechnology, 0, len(found))
+	for name, version := range found {
+		signature := d.byName[name].signature
+		detected = append(detected, DetectedTechnology{
+			Name:       name,
+			Categories: signature.Categories,
+			Version:    version,
+			Website:    signature.Website,
+		})
+	}
+	sort.Slice(detected, func(i, j int) bool {
+		return detected[i].Name < detected[j].Name
+	})
+	return detected
+}
+
+// compilePatterns compiles a list of patterns
+func compilePatterns(raw []string) ([]technologyPattern, error) {
+	patterns := make([]technologyPattern, 0, len(raw))
+	for _, value := range raw {
+		pattern, err := compilePattern(value)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// compilePatternMap compiles patterns keyed by header, meta or cookie name
+func compilePatternMap(raw map[string]string, lowercaseKeys bool) (map[string][]technologyPattern, error) {
+	patterns := make(map[string][]technologyPattern, len(raw))
+	for key, value := range raw {
+		pattern, err := compilePattern(value)
+		if err != nil {
+			return nil, err
+		}
+		if lowercaseKeys {
+			key = strings.ToLower(key)
+		}
+		patterns[key] = append(patterns[key], pattern)
+	}
+	return patterns, nil
+}
+
+// compilePattern compiles a pattern with optional `\;version:` and `\;confidence:` tags
+func compilePattern(raw string) (technologyPattern, error) {
+	parts := strings.Split(raw, `\;`)
+
+	regex, err := regexp.Compile("(?i)" + parts[0])
+	if err != nil {
+		return technologyPattern{}, fmt.Errorf("invalid pattern %q: %w", parts[0], err)
+	}
+
+	pattern := technologyPattern{regex: regex}
+	for _, tag := range parts[1:] {
+		if version, ok := strings.CutPrefix(tag, "version:"); ok {
+			pattern.version = version
+		}
+	}
+	return pattern, nil
+}
+
+// matchAny reports whether a pattern matches the value, with the version it captured
+func matchAny(patterns []technologyPattern, value string) (bool, string) {
+	matched := false
+	version := ""
+	for _, pattern := range patterns {
+		groups := pattern.regex.FindStringSubmatch(value)
+		if groups == nil {
+			continue
+		}
+		matched = true
+		if v := expandVersion(pattern.version, groups); len(v) > len(version) {
+			version = v
+		}
+	}
+	return matched, version
+}
+
+// expandVersion fills `\1`-style references of a version template with capture groups
+func expandVersion(template string, groups []string) string {
+	if template == "" {
+		return ""
+	}
+
+	version := versionReference.ReplaceAllStringFunc(template, func(ref string) string {
+		index, _ := strconv.Atoi(ref[1:])
+		if index < len(groups) {
+			return groups[index]
+		}
+		return ""
+	})
+	return strings.TrimSpace(version)
+}
+
+// limitedBuffer keeps the first bytes written to it and discards the rest
+type limitedBuffer struct {
+	data  []byte
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - len(b.data); remaining > 0 {
+		if len(p) > remaining {
+			b.data = append(b.data, p[:remaining]...)
+		} else {
+			b.data = append(b.data, p...)
+		}
+	}
+	return len(p), nil
+}
+
+// String returns the kept bytes
+func (b *limitedBuffer) String() string {
+	return string(b.data)
+}
+
+// extractMetaNames collects every named meta tag, as technology signatures can match any of them
+func extractMetaNames(doc *goquery.Document) map[string]string {
+	metaNames := make(map[string]string)
+	doc.Find("meta[name][content]").Each(func(i int, s *goquery.Selection) {
+		name := strings.ToLower(strings.TrimSpace(s.AttrOr("name", "")))
+		if _, exists := metaNames[name]; name != "" && !exists {
+			metaNames[name] = s.AttrOr("content", "")
+		}
+	})
+	return metaNames
+}
@@ -0,0 +1,107 @@
+package crawler
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDetectTechnologies(t *testing.T) {
+	detector, err := NewTechnologyDetector("")
+	if err != nil {
+		t.Fatalf("Failed to compile built-in signatures: %v", err)
+	}
+
+	html := `<html><head>
+		<meta name="generator" content="WordPress 6.4.2">
+		<link rel="stylesheet" href="https://example.com/wp-content/themes/site/style.css">
+		<script src="https://example.com/wp-includes/js/jquery/jquery.min.js?ver=3.7.1"></script>
+		<script src="https://code.jquery.com/jquery-3.7.1.min.js"></script>
+	</head><body>
+		<noscript><iframe src="https://www.googletagmanager.com/ns.html?id=GTM-ABC123"></iframe></noscript>
+	</body></html>`
+
+	parseResult, err := ParseHTML(strings.NewReader(html), "https://example.com/")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	var scripts []string
+	for _, resource := range parseResult.Resources {
+		if resource.Type == ResourceScript {
+			scripts = append(scripts, resource.URL)
+		}
+	}
+
+	headers := http.Header{}
+	headers.Set("Server", "nginx/1.25.3")
+	detected := detector.Detect(TechnologyEvidence{
+		Headers: headers,
+		Cookies: []*http.Cookie{{Name: "_ga", Value: "GA1.1.123"}},
+		Meta:    parseResult.MetaNames,
+		Scripts: scripts,
+		HTML:    html,
+	})
+
+	versions := make(map[string]string)
+	for _, tech := range detected {
+		versions[tech.Name] = tech.Version
+	}
+
+	expected := map[string]string{
+		"WordPress":          "6.4.2",
+		"PHP":                "", // Implied by WordPress
+		"MySQL":              "",
+		"jQuery":             "3.7.1",
+		"Nginx":              "1.25.3",
+		"Google Analytics":   "",
+		"Google Tag Manager": "",
+	}
+	for name, version := range expected {
+		got, found := versions[name]
+		if !found {
+			t.Errorf("Expected %s to be detected, got %v", name, versions)
+			continue
+		}
+		if got != version {
+			t.Errorf("%s: expected version %q, got %q", name, version, got)
+		}
+	}
+	if _, found := versions["Drupal"]; found {
+		t.Error("Did not expect Drupal to be detected")
+	}
+}
+
+func TestTechnologyDetectorExtraSignatures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signatures.json")
+	signatures := `{
+		"Internal Portal": {"categories": ["CMS"], "headers": {"X-Portal": "^v(\\d+)\\;version:\\1"}},
+		"Nginx": {"categories": ["Web servers"], "headers": {"Server": "^openresty$"}}
+	}`
+	if err := os.WriteFile(path, []byte(signatures), 0o600); err != nil {
+		t.Fatalf("Failed to write signatures: %v", err)
+	}
+
+	detector, err := NewTechnologyDetector(path)
+	if err != nil {
+		t.Fatalf("Failed to load extra signatures: %v", err)
+	}
+
+	headers := http.Header{}
+	headers.Set("X-Portal", "v7")
+	headers.Set("Server", "openresty")
+	detected := detector.Detect(TechnologyEvidence{Headers: headers})
+
+	if len(detected) != 2 || detected[0].Name != "Internal Portal" || detected[0].Version != "7" || detected[1].Name != "Nginx" {
+		t.Errorf("Unexpected detection with extra signatures: %+v", detected)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"Broken": {"html": ["(unclosed"]}}`), 0o600); err != nil {
+		t.Fatalf("Failed to write signatures: %v", err)
+	}
+	if _, err := NewTechnologyDetector(path); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+}
@@ -0,0 +1,211 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// Limits on what a probe reads and keeps
+const (
+	maxProbeBodyBytes   = 64 * 1024 // Read so that the connection can be reused
+	maxProbeErrorLength = 500
+)
+
+// ProbeResult is the outcome of fetching a page for uptime monitoring
+type ProbeResult struct {
+	URL          string
+	CheckedAt    time.Time
+	StatusCode   int           // Zero when no response arrived
+	ResponseTime time.Duration // Time until the response headers arrived
+	Up           bool          // Answered with a status below 400
+	Error        string        // Why the page is down
+}
+
+// UptimeIncident is a period during which consecutive probes found a page down
+type UptimeIncident struct {
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at"` // Nil while the page is still down
+	DurationSeconds int64      `json:"duration_seconds"`
+	Checks          int        `json:"checks"` // Failed probes during the incident
+	StatusCode      int        `json:"status_code"`
+	Error           string     `json:"error"` // Error of the first failed probe
+}
+
+// UptimeSummary describes the probes of a page over a time window
+type UptimeSummary struct {
+	Checks          int              `json:"checks"`
+	UpChecks        int              `json:"up_checks"`
+	UptimePercent   *float64         `json:"uptime_percent"` // Nil without probes in the window
+	AvgResponseMs   float64          `json:"avg_response_ms"`
+	P95ResponseMs   int64            `json:"p95_response_ms"`
+	MaxResponseMs   int64            `json:"max_response_ms"`
+	Incidents       []UptimeIncident `json:"incidents"`
+	DowntimeSeconds int64            `json:"downtime_seconds"`
+}
+
+// Probe fetches a page without parsing or analyzing it. It makes the same request as a
+// full crawl, so a page that can be analyzed is also up. Probes are paced by the host
+// scheduler and count toward the circuit breaker like the requests of a crawl.
+func (c *CrawlerService) Probe(ctx context.Context, targetURL string) ProbeResult {
+	result := ProbeResult{URL: targetURL, CheckedAt: time.Now()}
+
+	if !c.breaker.Allow(targetURL) {
+		result.Error = "host unreachable (circuit breaker open)"
+		return result
+	}
+
+	release, err := c.scheduler.Acquire(ctx, targetURL)
+	if err != nil {
+		result.Error = truncateRunes(err.Error(), maxProbeErrorLength)
+		return result
+	}
+	defer release()
+
+	started := time.Now()
+	resp, responseTime, err := c.fetchPage(ctx, targetURL)
+	if err != nil {
+		c.scheduler.Report(targetURL, 0, time.Since(started), 0)
+		if ctx.Err() == nil {
+			c.breaker.RecordFailure(targetURL)
+		}
+		result.Error = truncateRunes(err.Error(), maxProbeErrorLength)
+		return result
+	}
+	defer resp.Body.Close()
+	c.breaker.RecordSuccess(targetURL)
+
+	retryAfter, _ := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	c.scheduler.Report(targetURL, resp.StatusCode, time.Since(started), retryAfter)
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxProbeBodyBytes))
+
+	result.StatusCode = resp.StatusCode
+	result.ResponseTime = responseTime
+	result.Up = resp.StatusCode < 400
+	if !result.Up {
+		result.Error = fmt.Sprintf("HTTP %d", resp.StatusCode)
+	}
+	return result
+}
+
+// UptimeTotals aggregates the probes of a time window, whether counted here or by the database
+type UptimeTotals struct {
+	Checks          int
+	UpChecks        int
+	Responses       int // Probes that received a response
+	TotalResponseMs int64
+	MaxResponseMs   int64
+	P95ResponseMs   int64
+}
+
+// Summary combines the totals with the incidents of the same window
+func (t UptimeTotals) Summary(incidents []UptimeIncident) UptimeSummary {
+	summary := UptimeSummary{
+		Checks:        t.Checks,
+		UpChecks:      t.UpChecks,
+		P95ResponseMs: t.P95ResponseMs,
+		MaxResponseMs: t.MaxResponseMs,
+		Incidents:     incidents,
+	}
+	if summary.Incidents == nil {
+		summary.Incidents = []UptimeIncident{}
+	}
+
+	for _, incident := range summary.Incidents {
+		summary.DowntimeSeconds += incident.DurationSeconds
+	}
+	if t.Checks > 0 {
+		uptime := math.Round(float64(t.UpChecks)/float64(t.Checks)*100000) / 1000
+		summary.UptimePercent = &uptime
+	}
+	if t.Responses > 0 {
+		summary.AvgResponseMs = math.Round(float64(t.TotalResponseMs)/float64(t.Responses)*10) / 10
+	}
+
+	return summary
+}
+
+// P95Index returns the position of the 95th percentile among count sorted values
+func P95Index(count int) int {
+	return (count*95+99)/100 - 1
+}
+
+// SummarizeUptime computes uptime, response times and incidents from the probes checked
+// between since and until. Probes must be sorted by check time; others are ignored.
+// interval is the time between probes; see UptimeIncidents.
+func SummarizeUptime(probes []ProbeResult, since, until time.Time, interval time.Duration) UptimeSummary {
+	var totals UptimeTotals
+	var responseTimes []int64
+	for _, probe := range probes {
+		if probe.CheckedAt.Before(since) || probe.CheckedAt.After(until) {
+			continue
+		}
+		totals.Checks++
+		if probe.Up {
+			totals.UpChecks++
+		}
+
+		if probe.StatusCode > 0 {
+			ms := probe.ResponseTime.Milliseconds()
+			totals.Responses++
+			totals.TotalResponseMs += ms
+			responseTimes = append(responseTimes, ms)
+			if ms > totals.MaxResponseMs {
+				totals.MaxResponseMs = ms
+			}
+		}
+	}
+	if len(responseTimes) > 0 {
+		sort.Slice(responseTimes, func(i, j int) bool { return responseTimes[i] < responseTimes[j] })
+		totals.P95ResponseMs = responseTimes[P95Index(len(responseTimes))]
+	}
+
+	return totals.Summary(UptimeIncidents(probes, since, until, interval))
+}
+
+// UptimeIncidents finds the incidents among the probes checked between since and until.
+// Probes must be sorted by check time; only failed probes and the first successful probe
+// after each are needed. An incident still open at the last probe is counted until one
+// interval after it, as later probes would have been recorded, but no later than until.
+func UptimeIncidents(probes []ProbeResult, since, until time.Time, interval time.Duration) []UptimeIncident {
+	incidents := []UptimeIncident{}
+
+	var open *UptimeIncident
+	var lastChecked time.Time
+	for _, probe := range probes {
+		if probe.CheckedAt.Before(since) || probe.CheckedAt.After(until) {
+			continue
+		}
+		lastChecked = probe.CheckedAt
+
+		if probe.Up {
+			if open != nil {
+				// The page is back: close the incident at the first successful probe
+				endedAt := probe.CheckedAt
+				open.EndedAt = &endedAt
+				open.DurationSeconds = int64(endedAt.Sub(open.StartedAt).Seconds())
+				incidents = append(incidents, *open)
+				open = nil
+			}
+			continue
+		}
+
+		if open == nil {
+			open = &UptimeIncident{StartedAt: probe.CheckedAt, StatusCode: probe.StatusCode, Error: probe.Error}
+		}
+		open.Checks++
+	}
+	if open != nil {
+		end := lastChecked.Add(interval)
+		if end.After(until) {
+			end = until
+		}
+		open.DurationSeconds = int64(end.Sub(open.StartedAt).Seconds())
+		incidents = append(incidents, *open)
+	}
+
+	return incidents
+}
@@ -0,0 +1,148 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestProbe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("<html><body>ok</body></html>"))
+	}))
+
+	config := DefaultConfig()
+	config.MaxRetries = 0
+	crawler := NewCrawlerService(config)
+
+	up := crawler.Probe(context.Background(), server.URL+"/")
+	if !up.Up || up.StatusCode != http.StatusOK || up.Error != "" {
+		t.Errorf("Expected the page to be up, got %+v", up)
+	}
+
+	down := crawler.Probe(context.Background(), server.URL+"/down")
+	if down.Up || down.StatusCode != http.StatusServiceUnavailable || down.Error != "HTTP 503" {
+		t.Errorf("Expected the page to be down with HTTP 503, got %+v", down)
+	}
+
+	server.Close()
+	unreachable := crawler.Probe(context.Background(), server.URL+"/")
+	if unreachable.Up || unreachable.StatusCode != 0 || unreachable.Error == "" {
+		t.Errorf("Expected an unreachable page to be down with an error, got %+v", unreachable)
+	}
+}
+
+func TestProbeSharesCircuitBreaker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	config := DefaultConfig()
+	config.BreakerThreshold = 2
+	crawler := NewCrawlerService(config)
+	prober := crawler.WithRequestSettings(time.Second, 0, 0)
+	if prober.scheduler != crawler.scheduler || prober.breaker != crawler.breaker {
+		t.Fatal("Expected the prober to share the host scheduler and circuit breaker")
+	}
+
+	for i := 0; i < 2; i++ {
+		prober.Probe(context.Background(), server.URL+"/")
+	}
+	if crawler.breaker.Allow(server.URL + "/") {
+		t.Error("Expected failed probes to open the breaker of the crawls")
+	}
+
+	result := prober.Probe(context.Background(), server.URL+"/")
+	if result.Up || result.Error != "host unreachable (circuit breaker open)" {
+		t.Errorf("Expected the probe to be short-circuited, got %+v", result)
+	}
+}
+
+func TestSummarizeUptime(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	probe := func(minute int, up bool, status int, ms int) ProbeResult {
+		result := ProbeResult{
+			CheckedAt:    start.Add(time.Duration(minute) * time.Minute),
+			StatusCode:   status,
+			ResponseTime: time.Duration(ms) * time.Millisecond,
+			Up:           up,
+		}
+		if !up && status == 0 {
+			result.Error = "no response after 2 attempts: connection refused"
+		}
+		return result
+	}
+
+	probes := []ProbeResult{
+		probe(-10, false, 0, 0), // Before the window
+		probe(0, true, 200, 100),
+		probe(1, true, 200, 120),
+		probe(2, false, 503, 80),
+		probe(3, false, 0, 0),
+		probe(4, true, 200, 140),
+		probe(5, true, 200, 100),
+		probe(6, true, 200, 900),
+		probe(7, false, 0, 0),
+	}
+	until := start.Add(10 * time.Minute)
+
+	summary := SummarizeUptime(probes, start, until, time.Minute)
+	if summary.Checks != 8 || summary.UpChecks != 5 {
+		t.Fatalf("Expected 5 of 8 checks up, got %d of %d", summary.UpChecks, summary.Checks)
+	}
+	if summary.UptimePercent == nil || *summary.UptimePercent != 62.5 {
+		t.Errorf("Expected 62.5%% uptime, got %v", summary.UptimePercent)
+	}
+	if summary.AvgResponseMs != 240 || summary.MaxResponseMs != 900 || summary.P95ResponseMs != 900 {
+		t.Errorf("Unexpected response times: avg=%v p95=%d max=%d", summary.AvgResponseMs, summary.P95ResponseMs, summary.MaxResponseMs)
+	}
+
+	if len(summary.Incidents) != 2 {
+		t.Fatalf("Expected 2 incidents, got %d: %+v", len(summary.Incidents), summary.Incidents)
+	}
+	resolved := summary.Incidents[0]
+	if resolved.EndedAt == nil || resolved.DurationSeconds != 120 || resolved.Checks != 2 || resolved.StatusCode != 503 {
+		t.Errorf("Unexpected resolved incident: %+v", resolved)
+	}
+	ongoing := summary.Incidents[1]
+	if ongoing.EndedAt != nil || ongoing.DurationSeconds != 60 || ongoing.Error == "" {
+		t.Errorf("Expected an ongoing incident lasting one interval after the last probe, got %+v", ongoing)
+	}
+	if summary.DowntimeSeconds != 180 {
+		t.Errorf("Expected 180 seconds of downtime, got %d", summary.DowntimeSeconds)
+	}
+
+	// An ongoing incident never extends past the end of the window
+	summary = SummarizeUptime(probes, start, until, time.Hour)
+	if ongoing := summary.Incidents[1]; ongoing.DurationSeconds != 180 {
+		t.Errorf("Expected the ongoing incident to end with the window, got %d seconds", ongoing.DurationSeconds)
+	}
+
+	// Probes stopped long ago: the incident doesn't last until now
+	summary = SummarizeUptime(probes, start, until.Add(24*time.Hour), time.Minute)
+	if ongoing := summary.Incidents[1]; ongoing.DurationSeconds != 60 {
+		t.Errorf("Expected the ongoing incident to end one interval after the last probe, got %d seconds", ongoing.DurationSeconds)
+	}
+
+	// Incidents only need the failed probes and the recoveries
+	var transitions []ProbeResult
+	for i, p := range probes {
+		if !p.Up || (i > 0 && !probes[i-1].Up) {
+			transitions = append(transitions, p)
+		}
+	}
+	incidents := UptimeIncidents(transitions, start, until, time.Minute)
+	if len(incidents) != 2 || incidents[0].DurationSeconds != 120 || incidents[1].DurationSeconds != 60 {
+		t.Errorf("Expected the same incidents from the transitions alone, got %+v", incidents)
+	}
+
+	empty := SummarizeUptime(probes, until, until.Add(time.Hour), time.Minute)
+	if empty.Checks != 0 || empty.UptimePercent != nil || len(empty.Incidents) != 0 {
+		t.Errorf("Expected an empty summary without probes in the window, got %+v", empty)
+	}
+}
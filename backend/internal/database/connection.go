@@ -86,6 +86,34 @@ func AutoMigrate() error {
 		&models.URL{},
 		&models.AnalysisResult{},
 		&models.BrokenLink{},
+		&models.HreflangLink{},
+		&models.IndexingIssue{},
+		&models.SEOFinding{},
+		&models.SEORuleSetting{},
+		&models.Heading{},
+		&models.HeadingIssue{},
+		&models.Image{},
+		&models.ImageIssue{},
+		&models.MixedContent{},
+		&models.CrawlSettings{},
+		&models.LinkFilterRule{},
+		&models.SkippedLink{},
+		&models.LinkEdge{},
+		&models.LinkIssue{},
+		&models.Technology{},
+		&models.ThirdParty{},
+		&models.Form{},
+		&models.FormIssue{},
+		&models.AnalyzerSetting{},
+		&models.AnalyzerMetric{},
+		&models.AnalyzerFinding{},
+		&models.ExtractionRule{},
+		&models.Extraction{},
+		&models.Assertion{},
+		&models.AssertionRun{},
+		&models.AssertionResult{},
+		&models.Monitor{},
+		&models.UptimeCheck{},
 	)
 	
 	if err != nil {
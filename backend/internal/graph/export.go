@@ -0,0 +1,177 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Export formats
+const (
+	FormatDOT      = "dot"
+	FormatGraphML  = "graphml"
+	FormatNodeLink = "json"
+)
+
+// NodeLinkGraph is the JSON node-link format (as read by networkx, d3 and Gephi's JSON importer)
+type NodeLinkGraph struct {
+	Directed   bool           `json:"directed"`
+	Multigraph bool           `json:"multigraph"`
+	Graph      map[string]any `json:"graph"`
+	Nodes      []NodeLinkNode `json:"nodes"`
+	Links      []NodeLinkLink `json:"links"`
+}
+
+// NodeLinkNode is a node in the node-link format
+type NodeLinkNode struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Color  string `json:"color"`
+	Page   bool   `json:"page"`
+}
+
+// NodeLinkLink is an edge in the node-link format
+type NodeLinkLink struct {
+	Source     string `json:"source"`
+	Target     string `json:"target"`
+	AnchorText string `json:"anchor_text"`
+	NoFollow   bool   `json:"nofollow"`
+	Weight     int    `json:"weight"`
+}
+
+// IsValidFormat checks whether an export format is known
+func IsValidFormat(format string) bool {
+	return format == FormatDOT || format == FormatGraphML || format == FormatNodeLink
+}
+
+// ToNodeLink converts the graph to the JSON node-link format
+func ToNodeLink(g *Graph) NodeLinkGraph {
+	result := NodeLinkGraph{
+		Directed: true,
+		Graph:    map[string]any{},
+		Nodes:    make([]NodeLinkNode, 0, len(g.Nodes)),
+		Links:    make([]NodeLinkLink, 0, len(g.Edges)),
+	}
+
+	for _, node := range g.Nodes {
+		result.Nodes = append(result.Nodes, NodeLinkNode{ID: node.ID, Status: node.Status, Color: node.Color(), Page: node.Page})
+	}
+	for _, edge := range g.Edges {
+		result.Links = append(result.Links, NodeLinkLink{
+			Source:     edge.Source,
+			Target:     edge.Target,
+			AnchorText: edge.AnchorText,
+			NoFollow:   edge.NoFollow,
+			Weight:     edge.Weight,
+		})
+	}
+
+	return result
+}
+
+// WriteDOT renders the graph in Graphviz DOT
+func WriteDOT(w io.Writer, g *Graph) error {
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString("digraph links {\n")
+	bw.WriteString("  node [shape=box, style=filled, fontcolor=white];\n")
+	for _, node := range g.Nodes {
+		fmt.Fprintf(bw, "  %s [label=%s, fillcolor=%s, status=%s];\n",
+			dotQuote(node.ID), dotQuote(node.ID), dotQuote(node.Color()), dotQuote(node.Status))
+	}
+	for _, edge := range g.Edges {
+		attrs := []string{"weight=" + strconv.Itoa(edge.Weight)}
+		if edge.AnchorText != "" {
+			attrs = append(attrs, "label="+dotQuote(edge.AnchorText))
+		}
+		if edge.NoFollow {
+			attrs = append(attrs, "style=dashed")
+		}
+		fmt.Fprintf(bw, "  %s -> %s [%s];\n", dotQuote(edge.Source), dotQuote(edge.Target), strings.Join(attrs, ", "))
+	}
+	bw.WriteString("}\n")
+
+	return bw.Flush()
+}
+
+// dotQuote quotes a DOT identifier
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", " ")
+	return `"` + s + `"`
+}
+
+// graphMLKeys declares the GraphML attributes; r, g and b are picked up as node colors by Gephi
+var graphMLKeys = []struct {
+	id, target, name, kind string
+}{
+	{"label", "node", "label", "string"},
+	{"status", "node", "status", "string"},
+	{"color", "node", "color", "string"},
+	{"r", "node", "r", "int"},
+	{"g", "node", "g", "int"},
+	{"b", "node", "b", "int"},
+	{"page", "node", "page", "boolean"},
+	{"anchor", "edge", "anchor_text", "string"},
+	{"nofollow", "edge", "nofollow", "boolean"},
+	{"weight", "edge", "weight", "double"},
+}
+
+// WriteGraphML renders the graph in GraphML
+func WriteGraphML(w io.Writer, g *Graph) error {
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString(xml.Header)
+	bw.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	for _, key := range graphMLKeys {
+		fmt.Fprintf(bw, `  <key id="%s" for="%s" attr.name="%s" attr.type="%s"/>`+"\n", key.id, key.target, key.name, key.kind)
+	}
+	bw.WriteString(`  <graph id="links" edgedefault="directed">` + "\n")
+
+	ids := make(map[string]string, len(g.Nodes))
+	for i, node := range g.Nodes {
+		id := "n" + strconv.Itoa(i)
+		ids[node.ID] = id
+		r, gr, b := hexToRGB(node.Color())
+
+		fmt.Fprintf(bw, `    <node id="%s">`+"\n", id)
+		writeGraphMLData(bw, "label", node.ID)
+		writeGraphMLData(bw, "status", node.Status)
+		writeGraphMLData(bw, "color", node.Color())
+		writeGraphMLData(bw, "r", strconv.Itoa(r))
+		writeGraphMLData(bw, "g", strconv.Itoa(gr))
+		writeGraphMLData(bw, "b", strconv.Itoa(b))
+		writeGraphMLData(bw, "page", strconv.FormatBool(node.Page))
+		bw.WriteString("    </node>\n")
+	}
+	for i, edge := range g.Edges {
+		fmt.Fprintf(bw, `    <edge id="e%d" source="%s" target="%s">`+"\n", i, ids[edge.Source], ids[edge.Target])
+		writeGraphMLData(bw, "anchor", edge.AnchorText)
+		writeGraphMLData(bw, "nofollow", strconv.FormatBool(edge.NoFollow))
+		writeGraphMLData(bw, "weight", strconv.Itoa(edge.Weight))
+		bw.WriteString("    </edge>\n")
+	}
+
+	bw.WriteString("  </graph>\n</graphml>\n")
+	return bw.Flush()
+}
+
+// writeGraphMLData writes an escaped data element
+func writeGraphMLData(w *bufio.Writer, key, value string) {
+	fmt.Fprintf(w, `      <data key="%s">`, key)
+	xml.EscapeText(w, []byte(value))
+	w.WriteString("</data>\n")
+}
+
+// hexToRGB splits a "#rrggbb" color into its components
+func hexToRGB(color string) (int, int, int) {
+	value, err := strconv.ParseUint(strings.TrimPrefix(color, "#"), 16, 32)
+	if err != nil {
+		return 0, 0, 0
+	}
+	return int(value >> 16 & 0xff), int(value >> 8 & 0xff), int(value & 0xff)
+}
@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func buildTestGraph() *Graph {
+	builder := NewBuilder()
+	builder.AddPage("https://example.com/")
+	builder.AddLink("https://example.com/", "https://example.com/about", "About", false, true)
+	builder.AddLink("https://example.com/", "https://example.com/about", "About us", true, true)
+	builder.AddLink("https://example.com/", "https://example.com/gone", `The "old" page`, false, true)
+	builder.AddLink("https://example.com/", "https://other.com/", "Other", true, false)
+	builder.MarkBroken("https://example.com/gone")
+	return builder.Build()
+}
+
+func TestBuilder(t *testing.T) {
+	g := buildTestGraph()
+
+	statuses := map[string]string{}
+	for _, node := range g.Nodes {
+		statuses[node.ID] = node.Status
+	}
+	expected := map[string]string{
+		"https://example.com/":      StatusOK,
+		"https://example.com/about": StatusOK,
+		"https://example.com/gone":  StatusBroken,
+		"https://other.com/":        StatusExternal,
+	}
+	for id, status := range expected {
+		if statuses[id] != status {
+			t.Errorf("Node %s: expected status %s, got %s", id, status, statuses[id])
+		}
+	}
+
+	if len(g.Edges) != 3 {
+		t.Fatalf("Expected 3 merged edges, got %d", len(g.Edges))
+	}
+	about := g.Edges[0]
+	if about.Target != "https://example.com/about" || about.Weight != 2 || about.AnchorText != "About" || about.NoFollow {
+		t.Errorf("Unexpected merged edge: %+v", about)
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDOT(&buf, buildTestGraph()); err != nil {
+		t.Fatalf("WriteDOT failed: %v", err)
+	}
+
+	dot := buf.String()
+	for _, want := range []string{
+		"digraph links {",
+		`"https://example.com/gone" [label="https://example.com/gone", fillcolor="#c62828", status="broken"];`,
+		`"https://example.com/" -> "https://example.com/gone" [weight=1, label="The \"old\" page"];`,
+		`"https://example.com/" -> "https://other.com/" [weight=1, label="Other", style=dashed];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT output is missing %q:\n%s", want, dot)
+		}
+	}
+}
+
+func TestWriteGraphML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGraphML(&buf, buildTestGraph()); err != nil {
+		t.Fatalf("WriteGraphML failed: %v", err)
+	}
+
+	var doc struct {
+		Graph struct {
+			Nodes []struct {
+				ID   string `xml:"id,attr"`
+				Data []struct {
+					Key   string `xml:"key,attr"`
+					Value string `xml:",chardata"`
+				} `xml:"data"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("GraphML output is not valid XML: %v", err)
+	}
+
+	if len(doc.Graph.Nodes) != 4 || len(doc.Graph.Edges) != 3 {
+		t.Fatalf("Expected 4 nodes and 3 edges, got %d and %d", len(doc.Graph.Nodes), len(doc.Graph.Edges))
+	}
+
+	// Nodes are sorted by URL, so the broken page is the third node
+	data := map[string]string{}
+	for _, d := range doc.Graph.Nodes[2].Data {
+		data[d.Key] = d.Value
+	}
+	if data["label"] != "https://example.com/gone" || data["status"] != StatusBroken || data["r"] != "198" {
+		t.Errorf("Unexpected node data: %v", data)
+	}
+}
+
+func TestToNodeLink(t *testing.T) {
+	result := ToNodeLink(buildTestGraph())
+
+	if !result.Directed || len(result.Nodes) != 4 || len(result.Links) != 3 {
+		t.Fatalf("Unexpected node-link graph: %+v", result)
+	}
+	if result.Nodes[3].ID != "https://other.com/" || result.Nodes[3].Color != "#757575" {
+		t.Errorf("Unexpected external node: %+v", result.Nodes[3])
+	}
+}
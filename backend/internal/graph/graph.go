@@ -0,0 +1,131 @@
+package graph
+
+import (
+	"sort"
+)
+
+// Node statuses
+const (
+	StatusOK       = "ok"
+	StatusBroken   = "broken"
+	StatusExternal = "external"
+)
+
+// statusColors are the hex colors nodes are rendered in
+var statusColors = map[string]string{
+	StatusOK:       "#2e7d32",
+	StatusBroken:   "#c62828",
+	StatusExternal: "#757575",
+}
+
+// Node is a page or link target in the link graph
+type Node struct {
+	ID     string // The URL
+	Status string
+	Page   bool // Whether the node is an analyzed page rather than only a link target
+}
+
+// Color returns the hex color of the node's status
+func (n Node) Color() string {
+	return statusColors[n.Status]
+}
+
+// Edge is a link between two nodes; repeated links are merged and counted in Weight
+type Edge struct {
+	Source     string
+	Target     string
+	AnchorText string // Anchor text of the first link
+	NoFollow   bool   // Set when every merged link is nofollow
+	Weight     int
+}
+
+// Graph is a directed link graph
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+}
+
+// Builder collects pages and links and builds a Graph with sorted nodes and edges
+type Builder struct {
+	nodes  map[string]*Node
+	edges  map[[2]string]*Edge
+	broken map[string]bool
+}
+
+// NewBuilder creates an empty graph builder
+func NewBuilder() *Builder {
+	return &Builder{
+		nodes:  make(map[string]*Node),
+		edges:  make(map[[2]string]*Edge),
+		broken: make(map[string]bool),
+	}
+}
+
+// AddPage adds an analyzed page
+func (b *Builder) AddPage(url string) {
+	node := b.node(url, true)
+	node.Page = true
+	node.Status = StatusOK
+}
+
+// AddLink adds a link from a page to a target
+func (b *Builder) AddLink(source, target, anchorText string, noFollow, internal bool) {
+	b.AddPage(source)
+
+	b.node(target, internal)
+
+	key := [2]string{source, target}
+	edge, exists := b.edges[key]
+	if !exists {
+		b.edges[key] = &Edge{Source: source, Target: target, AnchorText: anchorText, NoFollow: noFollow, Weight: 1}
+		return
+	}
+	edge.Weight++
+	edge.NoFollow = edge.NoFollow && noFollow
+}
+
+// MarkBroken marks a link target as broken
+func (b *Builder) MarkBroken(url string) {
+	b.broken[url] = true
+}
+
+// Build returns the graph with nodes sorted by URL and edges by source and target
+func (b *Builder) Build() *Graph {
+	g := &Graph{Nodes: []Node{}, Edges: []Edge{}}
+
+	for _, node := range b.nodes {
+		if b.broken[node.ID] {
+			node.Status = StatusBroken
+		}
+		g.Nodes = append(g.Nodes, *node)
+	}
+	for _, edge := range b.edges {
+		g.Edges = append(g.Edges, *edge)
+	}
+
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].ID < g.Nodes[j].ID
+	})
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].Source != g.Edges[j].Source {
+			return g.Edges[i].Source < g.Edges[j].Source
+		}
+		return g.Edges[i].Target < g.Edges[j].Target
+	})
+
+	return g
+}
+
+// node returns the node for a URL, creating it on first use
+func (b *Builder) node(url string, internal bool) *Node {
+	node, exists := b.nodes[url]
+	if !exists {
+		status := StatusOK
+		if !internal {
+			status = StatusExternal
+		}
+		node = &Node{ID: url, Status: status}
+		b.nodes[url] = node
+	}
+	return node
+}
@@ -7,28 +7,62 @@ import (
 )
 
 type AnalysisResult struct {
-	ID            uint           `gorm:"primaryKey" json:"id"`
-	URLID         uint           `gorm:"not null;uniqueIndex" json:"url_id"`
-	HTMLVersion   string         `gorm:"size:50" json:"html_version"`
-	Title         string         `gorm:"size:255" json:"title"`
-	InternalLinks int            `gorm:"default:0" json:"internal_links"`
-	ExternalLinks int            `gorm:"default:0" json:"external_links"`
-	BrokenLinks   int            `gorm:"default:0" json:"broken_links"`
-	HasLoginForm  bool           `gorm:"default:false" json:"has_login_form"`
-	H1Count       int            `gorm:"default:0" json:"h1_count"`
-	H2Count       int            `gorm:"default:0" json:"h2_count"`
-	H3Count       int            `gorm:"default:0" json:"h3_count"`
-	H4Count       int            `gorm:"default:0" json:"h4_count"`
-	H5Count       int            `gorm:"default:0" json:"h5_count"`
-	H6Count       int            `gorm:"default:0" json:"h6_count"`
-	AnalyzedAt    *time.Time     `json:"analyzed_at"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
-	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                    uint           `gorm:"primaryKey" json:"id"`
+	URLID                 uint           `gorm:"not null;uniqueIndex" json:"url_id"`
+	HTMLVersion           string         `gorm:"size:50" json:"html_version"`
+	Title                 string         `gorm:"size:255" json:"title"`
+	InternalLinks         int            `gorm:"default:0" json:"internal_links"`
+	ExternalLinks         int            `gorm:"default:0" json:"external_links"`
+	BrokenLinks           int            `gorm:"default:0" json:"broken_links"`
+	HasLoginForm          bool           `gorm:"default:false" json:"has_login_form"`
+	H1Count               int            `gorm:"default:0" json:"h1_count"`
+	H2Count               int            `gorm:"default:0" json:"h2_count"`
+	H3Count               int            `gorm:"default:0" json:"h3_count"`
+	H4Count               int            `gorm:"default:0" json:"h4_count"`
+	H5Count               int            `gorm:"default:0" json:"h5_count"`
+	H6Count               int            `gorm:"default:0" json:"h6_count"`
+	CanonicalURL          string         `gorm:"size:2048" json:"canonical_url"`
+	RobotsMeta            string         `gorm:"size:500" json:"robots_meta"`
+	XRobotsTag            string         `gorm:"size:500" json:"x_robots_tag"`
+	NoIndex               bool           `gorm:"default:false" json:"noindex"`
+	NoFollow              bool           `gorm:"default:false" json:"nofollow"`
+	SEOScore              int            `gorm:"default:0" json:"seo_score"`
+	WordCount             int            `gorm:"default:0" json:"word_count"`
+	SentenceCount         int            `gorm:"default:0" json:"sentence_count"`
+	ReadingEase           float64        `gorm:"default:0" json:"reading_ease"`    // Flesch reading ease
+	TextHTMLRatio         float64        `gorm:"default:0" json:"text_html_ratio"` // Percentage of visible text in the HTML
+	Language              string         `gorm:"size:10" json:"language"`
+	ContentHash           uint64         `gorm:"default:0;index" json:"content_hash,string"` // SimHash of the main text
+	MetaDescription       string         `gorm:"size:1000" json:"meta_description"`
+	ConsentBanner         bool           `gorm:"default:false" json:"consent_banner"`
+	ConsentProvider       string         `gorm:"size:100" json:"consent_provider"`
+	TrackersBeforeConsent int            `gorm:"default:0" json:"trackers_before_consent"` // Trackers loaded before the consent banner
+	AnalyzedAt            *time.Time     `json:"analyzed_at"`
+	CreatedAt             time.Time      `json:"created_at"`
+	UpdatedAt             time.Time      `json:"updated_at"`
+	DeletedAt             gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relationships
-	URL                URL          `gorm:"foreignKey:URLID" json:"url,omitempty"`
-	BrokenLinksDetails []BrokenLink `gorm:"foreignKey:AnalysisID" json:"broken_links_details,omitempty"`
+	URL                URL               `gorm:"foreignKey:URLID" json:"url,omitempty"`
+	BrokenLinksDetails []BrokenLink      `gorm:"foreignKey:AnalysisID" json:"broken_links_details,omitempty"`
+	HreflangLinks      []HreflangLink    `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"hreflang_links,omitempty"`
+	IndexingIssues     []IndexingIssue   `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"indexing_issues,omitempty"`
+	SEOFindings        []SEOFinding      `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"seo_findings,omitempty"`
+	Headings           []Heading         `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"headings,omitempty"`
+	HeadingIssues      []HeadingIssue    `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"heading_issues,omitempty"`
+	Images             []Image           `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"images,omitempty"`
+	ImageIssues        []ImageIssue      `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"image_issues,omitempty"`
+	MixedContent       []MixedContent    `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"mixed_content,omitempty"`
+	SkippedLinks       []SkippedLink     `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"skipped_links,omitempty"`
+	LinkEdges          []LinkEdge        `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"link_edges,omitempty"`
+	LinkIssues         []LinkIssue       `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"link_issues,omitempty"`
+	Technologies       []Technology      `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"technologies,omitempty"`
+	ThirdParties       []ThirdParty      `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"third_parties,omitempty"`
+	Forms              []Form            `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"forms,omitempty"`
+	FormIssues         []FormIssue       `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"form_issues,omitempty"`
+	AnalyzerMetrics    []AnalyzerMetric  `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"analyzer_metrics,omitempty"`
+	AnalyzerFindings   []AnalyzerFinding `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"analyzer_findings,omitempty"`
+	Extractions        []Extraction      `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"extractions,omitempty"`
 }
 
 // TableName returns the table name for the AnalysisResult model
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+// AnalyzerSetting stores whether a page analyzer is enabled for a user.
+// Analyzers without a setting row are enabled.
+type AnalyzerSetting struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"not null;uniqueIndex:idx_analyzer_user" json:"user_id"`
+	AnalyzerID string    `gorm:"not null;size:50;uniqueIndex:idx_analyzer_user" json:"analyzer_id"`
+	Enabled    bool      `gorm:"not null" json:"enabled"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for the AnalyzerSetting model
+func (AnalyzerSetting) TableName() string {
+	return "analyzer_settings"
+}
+
+// AnalyzerMetric stores a named value measured by a page analyzer
+type AnalyzerMetric struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID uint      `gorm:"not null;index" json:"analysis_id"`
+	AnalyzerID string    `gorm:"not null;size:50;index" json:"analyzer_id"`
+	Name       string    `gorm:"not null;size:100" json:"name"`
+	Value      float64   `gorm:"not null" json:"value"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the AnalyzerMetric model
+func (AnalyzerMetric) TableName() string {
+	return "analyzer_metrics"
+}
+
+// AnalyzerFinding stores a problem reported by a page analyzer
+type AnalyzerFinding struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID uint      `gorm:"not null;index" json:"analysis_id"`
+	AnalyzerID string    `gorm:"not null;size:50;index" json:"analyzer_id"`
+	Severity   string    `gorm:"not null;size:20" json:"severity"`
+	Message    string    `gorm:"size:600" json:"message"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the AnalyzerFinding model
+func (AnalyzerFinding) TableName() string {
+	return "analyzer_findings"
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+)
+
+// Assertion stores a check that a URL must pass after each crawl, such as an expected
+// status code or text that must not appear on the page
+type Assertion struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	URLID     uint      `gorm:"not null;index" json:"url_id"`
+	Type      string    `gorm:"not null;size:30" json:"type"`
+	Value     string    `gorm:"not null;size:1000" json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for the Assertion model
+func (Assertion) TableName() string {
+	return "assertions"
+}
+
+// AssertionRun records the outcome of a URL's assertions for one crawl. Runs are kept
+// across re-runs of the analysis so that failures can be followed over time.
+type AssertionRun struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	URLID          uint      `gorm:"not null;index" json:"url_id"`
+	Passed         bool      `gorm:"default:false" json:"passed"`
+	Total          int       `gorm:"default:0" json:"total"`
+	Failed         int       `gorm:"default:0" json:"failed"`
+	StatusCode     int       `gorm:"default:0" json:"status_code"`
+	ResponseTimeMs int64     `gorm:"default:0" json:"response_time_ms"`
+	CreatedAt      time.Time `gorm:"index" json:"created_at"`
+
+	// Relationships
+	Results []AssertionResult `gorm:"foreignKey:RunID;constraint:OnDelete:CASCADE" json:"results,omitempty"`
+}
+
+// TableName returns the table name for the AssertionRun model
+func (AssertionRun) TableName() string {
+	return "assertion_runs"
+}
+
+// AssertionResult stores the outcome of one assertion in a run
+type AssertionResult struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	RunID       uint      `gorm:"not null;index" json:"run_id"`
+	AssertionID uint      `gorm:"not null;index" json:"assertion_id"`
+	Type        string    `gorm:"not null;size:30" json:"type"`
+	Expected    string    `gorm:"size:1000" json:"expected"`
+	Actual      string    `gorm:"size:1000" json:"actual"`
+	Passed      bool      `gorm:"default:false" json:"passed"`
+	Message     string    `gorm:"size:600" json:"message"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the AssertionResult model
+func (AssertionResult) TableName() string {
+	return "assertion_results"
+}
@@ -7,14 +7,18 @@ import (
 )
 
 type BrokenLink struct {
-	ID         uint           `gorm:"primaryKey" json:"id"`
-	AnalysisID uint           `gorm:"not null;index" json:"analysis_id"`
-	URL        string         `gorm:"not null;size:2048" json:"url"`
-	StatusCode int            `gorm:"not null" json:"status_code"`
-	Error      string         `gorm:"size:500" json:"error"`
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
-	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	AnalysisID   uint           `gorm:"not null;index" json:"analysis_id"`
+	URL          string         `gorm:"not null;size:2048" json:"url"`
+	StatusCode   int            `gorm:"not null" json:"status_code"`
+	Error        string         `gorm:"size:500" json:"error"`
+	ResourceType string         `gorm:"size:20;default:'link';index" json:"resource_type"`
+	Class        string         `gorm:"size:20;default:'broken';index" json:"class"`
+	SoftNotFound bool           `gorm:"default:false" json:"soft_not_found"`
+	Confidence   float64        `gorm:"default:0" json:"confidence"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relationships
 	Analysis AnalysisResult `gorm:"foreignKey:AnalysisID" json:"analysis,omitempty"`
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// CrawlSettings stores the optional crawl modes chosen for a URL.
+// URLs without a settings row use the defaults.
+type CrawlSettings struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	URLID          uint      `gorm:"not null;uniqueIndex" json:"url_id"`
+	DetectSoft404  bool      `gorm:"not null;default:false" json:"detect_soft_404"`
+	CountedClasses []string  `gorm:"serializer:json;size:255" json:"counted_classes"` // Link classes counted as broken, nil for the defaults
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for the CrawlSettings model
+func (CrawlSettings) TableName() string {
+	return "crawl_settings"
+}
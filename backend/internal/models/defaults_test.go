@@ -0,0 +1,79 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// insertedValues returns the column values of the INSERT statement gorm builds when saving
+// a new row, without connecting to a database
+func insertedValues(t *testing.T, value interface{}) map[string]interface{} {
+	t.Helper()
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "user:password@tcp(127.0.0.1:3306)/test?parseTime=True",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("Failed to open dry-run database: %v", err)
+	}
+
+	result := db.Save(value)
+	if result.Error != nil {
+		t.Fatalf("Failed to build INSERT statement: %v", result.Error)
+	}
+	stmt := result.Statement
+	sql := stmt.SQL.String()
+	start, end := strings.Index(sql, "("), strings.Index(sql, ")")
+	if !strings.HasPrefix(sql, "INSERT") || start < 0 || end < start {
+		t.Fatalf("Expected an INSERT statement, got %q", sql)
+	}
+
+	columns := strings.Split(sql[start+1:end], ",")
+	if len(columns) != len(stmt.Vars) {
+		t.Fatalf("Expected %d values in %q, got %d", len(columns), sql, len(stmt.Vars))
+	}
+
+	values := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		values[strings.Trim(column, "` ")] = stmt.Vars[i]
+	}
+	return values
+}
+
+func TestSaveNewSEORuleSettingKeepsDisabled(t *testing.T) {
+	values := insertedValues(t, &SEORuleSetting{URLID: 1, RuleID: "title-length", Enabled: false})
+	if values["enabled"] != false {
+		t.Errorf("Expected a disabled rule to be inserted as disabled, got %v", values["enabled"])
+	}
+}
+
+func TestSaveNewAnalyzerSettingKeepsDisabled(t *testing.T) {
+	values := insertedValues(t, &AnalyzerSetting{UserID: 1, AnalyzerID: "security-headers", Enabled: false})
+	if values["enabled"] != false {
+		t.Errorf("Expected a disabled analyzer to be inserted as disabled, got %v", values["enabled"])
+	}
+}
+
+func TestSaveNewHeadingKeepsFirstParent(t *testing.T) {
+	// Children of the first heading have parent position 0
+	values := insertedValues(t, &Heading{AnalysisID: 1, Position: 1, Level: 2, Depth: 1, ParentPosition: 0})
+	if values["parent_position"] != 0 {
+		t.Errorf("Expected parent position 0 to be inserted, got %v", values["parent_position"])
+	}
+
+	values = insertedValues(t, &Heading{AnalysisID: 1, Position: 0, Level: 1, ParentPosition: -1})
+	if values["parent_position"] != -1 {
+		t.Errorf("Expected parent position -1 for a top-level heading, got %v", values["parent_position"])
+	}
+}
+
+func TestSaveNewImageKeepsEmptyContentLength(t *testing.T) {
+	values := insertedValues(t, &Image{AnalysisID: 1, URL: "https://example.com/empty.gif", ContentLength: 0})
+	if values["content_length"] != int64(0) {
+		t.Errorf("Expected a 0-byte content length to be inserted, got %v", values["content_length"])
+	}
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+)
+
+// ExtractionRule stores a CSS selector or regular expression whose matches are stored with
+// each analysis. Rules without a URL apply to every URL of the user, optionally narrowed to
+// a group of URLs by host suffix and path pattern.
+type ExtractionRule struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	UserID      uint      `gorm:"not null;index" json:"user_id"`
+	URLID       *uint     `gorm:"index" json:"url_id"`
+	Name        string    `gorm:"not null;size:100" json:"name"`
+	Type        string    `gorm:"not null;size:10" json:"type"` // css or regex
+	Expression  string    `gorm:"not null;size:1000" json:"expression"`
+	Attribute   string    `gorm:"size:100" json:"attribute"`                     // Attribute read from CSS matches, empty for their text
+	Mode        string    `gorm:"not null;size:10;default:'single'" json:"mode"` // single or list
+	HostSuffix  string    `gorm:"size:255" json:"host_suffix"`
+	PathPattern string    `gorm:"size:500" json:"path_pattern"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for the ExtractionRule model
+func (ExtractionRule) TableName() string {
+	return "extraction_rules"
+}
+
+// Extraction stores the values an extraction rule read from an analyzed page
+type Extraction struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID uint      `gorm:"not null;index" json:"analysis_id"`
+	RuleID     uint      `gorm:"not null;index" json:"rule_id"`
+	Name       string    `gorm:"not null;size:100" json:"name"`
+	Mode       string    `gorm:"not null;size:10" json:"mode"`
+	Values     []string  `gorm:"serializer:json;type:mediumtext" json:"values"` // Up to 100 values of 1000 characters
+	Found      bool      `gorm:"default:false" json:"found"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the Extraction model
+func (Extraction) TableName() string {
+	return "extractions"
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+// FormField is an input, select or textarea of a form
+type FormField struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Autocomplete string `json:"autocomplete,omitempty"`
+	Required     bool   `json:"required"`
+}
+
+// Form stores a form found on an analyzed page
+type Form struct {
+	ID           uint        `gorm:"primaryKey" json:"id"`
+	AnalysisID   uint        `gorm:"not null;index" json:"analysis_id"`
+	Position     int         `gorm:"not null" json:"position"` // Order of the form on the page
+	FormID       string      `gorm:"size:255" json:"form_id"`  // The id attribute of the form
+	Method       string      `gorm:"not null;size:10" json:"method"`
+	Action       string      `gorm:"size:2048" json:"action"` // Resolved submission URL
+	Secure       bool        `gorm:"default:false" json:"secure"`
+	CrossOrigin  bool        `gorm:"default:false" json:"cross_origin"`
+	Type         string      `gorm:"not null;size:20;index" json:"type"` // login, signup, search, newsletter, contact, payment or other
+	HasPassword  bool        `gorm:"default:false" json:"has_password"`
+	HasCSRFToken bool        `gorm:"default:false" json:"has_csrf_token"`
+	Fields       []FormField `gorm:"serializer:json;type:mediumtext" json:"fields"` // Up to 100 fields
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+// TableName returns the table name for the Form model
+func (Form) TableName() string {
+	return "forms"
+}
+
+// FormIssue stores a security problem with a form, such as a password submitted over HTTP
+type FormIssue struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID uint      `gorm:"not null;index" json:"analysis_id"`
+	Type       string    `gorm:"not null;size:50" json:"type"`
+	Position   int       `gorm:"not null" json:"position"` // Position of the form on the page
+	URL        string    `gorm:"size:2048" json:"url"`
+	Message    string    `gorm:"size:500" json:"message"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the FormIssue model
+func (FormIssue) TableName() string {
+	return "form_issues"
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+)
+
+// Heading stores an entry of an analyzed page's heading outline
+type Heading struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID     uint      `gorm:"not null;index" json:"analysis_id"`
+	Position       int       `gorm:"not null" json:"position"`
+	Level          int       `gorm:"not null" json:"level"`
+	Text           string    `gorm:"size:500" json:"text"`
+	Depth          int       `gorm:"not null;default:0" json:"depth"`
+	ParentPosition int       `gorm:"not null" json:"parent_position"` // -1 for top-level headings
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the Heading model
+func (Heading) TableName() string {
+	return "headings"
+}
+
+// HeadingIssue stores a structural problem found in a heading outline
+type HeadingIssue struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID uint      `gorm:"not null;index" json:"analysis_id"`
+	Type       string    `gorm:"not null;size:50" json:"type"`
+	Position   int       `gorm:"not null" json:"position"`
+	Message    string    `gorm:"size:500" json:"message"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the HeadingIssue model
+func (HeadingIssue) TableName() string {
+	return "heading_issues"
+}
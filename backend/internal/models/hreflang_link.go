@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// HreflangLink stores an alternate language link declared by an analyzed page
+type HreflangLink struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID uint      `gorm:"not null;index" json:"analysis_id"`
+	Lang       string    `gorm:"not null;size:35" json:"lang"`
+	URL        string    `gorm:"not null;size:2048" json:"url"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the HreflangLink model
+func (HreflangLink) TableName() string {
+	return "hreflang_links"
+}
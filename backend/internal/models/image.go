@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+)
+
+// Image stores an image referenced by an analyzed page
+type Image struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID    uint      `gorm:"not null;index" json:"analysis_id"`
+	URL           string    `gorm:"not null;size:2048" json:"url"`
+	Element       string    `gorm:"size:20" json:"element"`
+	Descriptor    string    `gorm:"size:20" json:"descriptor"`
+	Alt           string    `gorm:"size:1000" json:"alt"`
+	HasAlt        bool      `gorm:"default:false" json:"has_alt"`
+	Width         int       `gorm:"default:0" json:"width"`
+	Height        int       `gorm:"default:0" json:"height"`
+	Lazy          bool      `gorm:"default:false" json:"lazy"`
+	StatusCode    int       `gorm:"default:0" json:"status_code"`
+	ContentLength int64     `gorm:"not null" json:"content_length"` // -1 when unknown
+	Broken        bool      `gorm:"default:false" json:"broken"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the Image model
+func (Image) TableName() string {
+	return "images"
+}
+
+// ImageIssue stores an accessibility or performance problem with an image
+type ImageIssue struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID uint      `gorm:"not null;index" json:"analysis_id"`
+	Type       string    `gorm:"not null;size:50" json:"type"`
+	URL        string    `gorm:"size:2048" json:"url"`
+	Message    string    `gorm:"size:500" json:"message"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the ImageIssue model
+func (ImageIssue) TableName() string {
+	return "image_issues"
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// IndexingIssue stores a canonical, hreflang or robots problem found on an analyzed page
+type IndexingIssue struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID uint      `gorm:"not null;index" json:"analysis_id"`
+	Type       string    `gorm:"not null;size:50;index" json:"type"`
+	URL        string    `gorm:"size:2048" json:"url"`
+	Message    string    `gorm:"size:500" json:"message"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the IndexingIssue model
+func (IndexingIssue) TableName() string {
+	return "indexing_issues"
+}
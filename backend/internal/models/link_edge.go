@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+)
+
+// LinkEdge stores a link from an analyzed page (the source URL) to a target URL
+type LinkEdge struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID    uint      `gorm:"not null;index" json:"analysis_id"`
+	URLID         uint      `gorm:"not null;index" json:"url_id"` // Source page
+	TargetURL     string    `gorm:"not null;size:2048;index:idx_link_edges_target,length:191" json:"target_url"`
+	AnchorText    string    `gorm:"size:500" json:"anchor_text"`
+	Title         string    `gorm:"size:500" json:"title"`
+	Rel           string    `gorm:"size:255" json:"rel"`
+	NoFollow      bool      `gorm:"not null;default:false" json:"nofollow"`
+	Sponsored     bool      `gorm:"not null;default:false" json:"sponsored"`
+	UGC           bool      `gorm:"not null;default:false" json:"ugc"`
+	Internal      bool      `gorm:"not null;default:false;index" json:"internal"`
+	Image         bool      `gorm:"not null;default:false" json:"image"`
+	BlankNoOpener bool      `gorm:"not null;default:false" json:"blank_without_noopener"` // target="_blank" without rel="noopener"
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the LinkEdge model
+func (LinkEdge) TableName() string {
+	return "link_edges"
+}
+
+// LinkIssue stores an SEO or accessibility problem with a link, such as a generic anchor text
+type LinkIssue struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID uint      `gorm:"not null;index" json:"analysis_id"`
+	Type       string    `gorm:"not null;size:50;index" json:"type"`
+	URL        string    `gorm:"size:2048" json:"url"`
+	AnchorText string    `gorm:"size:500" json:"anchor_text"`
+	Message    string    `gorm:"size:500" json:"message"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the LinkIssue model
+func (LinkIssue) TableName() string {
+	return "link_issues"
+}
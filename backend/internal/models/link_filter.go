@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// LinkFilterRule stores an include/exclude rule for the link checker.
+// Rules without a URL apply to every URL of the user.
+type LinkFilterRule struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	UserID      uint      `gorm:"not null;index" json:"user_id"`
+	URLID       *uint     `gorm:"index" json:"url_id"`
+	Action      string    `gorm:"not null;size:10" json:"action"`
+	HostSuffix  string    `gorm:"size:255" json:"host_suffix"`
+	PathPattern string    `gorm:"size:500" json:"path_pattern"`
+	PatternType string    `gorm:"not null;size:10;default:'glob'" json:"pattern_type"`
+	Reason      string    `gorm:"size:255" json:"reason"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for the LinkFilterRule model
+func (LinkFilterRule) TableName() string {
+	return "link_filter_rules"
+}
+
+// SkippedLink stores a link that was not checked because of a filter rule
+type SkippedLink struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID uint      `gorm:"not null;index" json:"analysis_id"`
+	URL        string    `gorm:"not null;size:2048" json:"url"`
+	Reason     string    `gorm:"size:500" json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the SkippedLink model
+func (SkippedLink) TableName() string {
+	return "skipped_links"
+}
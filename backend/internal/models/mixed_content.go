@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// MixedContent stores an HTTP resource or form target found on an HTTPS page
+type MixedContent struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID uint      `gorm:"not null;index" json:"analysis_id"`
+	URL        string    `gorm:"not null;size:2048" json:"url"`
+	Element    string    `gorm:"not null;size:20" json:"element"`
+	Attribute  string    `gorm:"not null;size:20" json:"attribute"`
+	Type       string    `gorm:"not null;size:10;index" json:"type"` // active or passive
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the MixedContent model
+func (MixedContent) TableName() string {
+	return "mixed_content"
+}
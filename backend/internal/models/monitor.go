@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+)
+
+// Monitor stores the uptime monitoring settings of a URL. Monitoring only fetches the page,
+// so it can run far more often than a full analysis.
+type Monitor struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	UserID          uint       `gorm:"not null;index" json:"user_id"`
+	URLID           uint       `gorm:"not null;uniqueIndex" json:"url_id"`
+	Enabled         bool       `gorm:"default:false" json:"enabled"`
+	IntervalSeconds int        `gorm:"not null;default:60" json:"interval_seconds"`
+	LastCheckedAt   *time.Time `json:"last_checked_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// TableName returns the table name for the Monitor model
+func (Monitor) TableName() string {
+	return "monitors"
+}
+
+// UptimeCheck stores the outcome of one monitoring probe; together they form the uptime
+// time series of a URL
+type UptimeCheck struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	URLID          uint      `gorm:"not null;index:idx_uptime_url_checked,priority:1" json:"url_id"`
+	CheckedAt      time.Time `gorm:"not null;index:idx_uptime_url_checked,priority:2;index" json:"checked_at"`
+	StatusCode     int       `gorm:"default:0" json:"status_code"`
+	ResponseTimeMs int64     `gorm:"default:0" json:"response_time_ms"`
+	Up             bool      `gorm:"default:false" json:"up"`
+	Error          string    `gorm:"size:500" json:"error"`
+}
+
+// TableName returns the table name for the UptimeCheck model
+func (UptimeCheck) TableName() string {
+	return "uptime_checks"
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+// SEOFinding stores a finding produced by the on-page SEO audit
+type SEOFinding struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID uint      `gorm:"not null;index" json:"analysis_id"`
+	RuleID     string    `gorm:"not null;size:50;index" json:"rule_id"`
+	Severity   string    `gorm:"not null;size:20" json:"severity"`
+	Message    string    `gorm:"size:500" json:"message"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the SEOFinding model
+func (SEOFinding) TableName() string {
+	return "seo_findings"
+}
+
+// SEORuleSetting stores whether an SEO rule is enabled for a URL.
+// Rules without a setting row are enabled.
+type SEORuleSetting struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	URLID     uint      `gorm:"not null;uniqueIndex:idx_seo_rule_url" json:"url_id"`
+	RuleID    string    `gorm:"not null;size:50;uniqueIndex:idx_seo_rule_url" json:"rule_id"`
+	Enabled   bool      `gorm:"not null" json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for the SEORuleSetting model
+func (SEORuleSetting) TableName() string {
+	return "seo_rule_settings"
+}
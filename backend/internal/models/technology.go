@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// Technology stores a CMS, framework, analytics tool or server software detected on a page
+type Technology struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID uint      `gorm:"not null;index" json:"analysis_id"`
+	Name       string    `gorm:"not null;size:100;index" json:"name"`
+	Version    string    `gorm:"size:50" json:"version"`
+	Categories []string  `gorm:"serializer:json;size:255" json:"categories"`
+	Website    string    `gorm:"size:255" json:"website"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the Technology model
+func (Technology) TableName() string {
+	return "technologies"
+}
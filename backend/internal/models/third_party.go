@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// ThirdParty stores an external domain a page loads or links to through one kind of element
+type ThirdParty struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID    uint      `gorm:"not null;index" json:"analysis_id"`
+	Domain        string    `gorm:"not null;size:255;index" json:"domain"`
+	Company       string    `gorm:"size:100" json:"company"`
+	Category      string    `gorm:"not null;size:20;index" json:"category"` // advertising, analytics, social, cdn, consent or other
+	Element       string    `gorm:"not null;size:10" json:"element"`        // script, iframe, pixel or link
+	URL           string    `gorm:"not null;size:2048" json:"url"`          // First URL seen on the page
+	Count         int       `gorm:"default:1" json:"count"`
+	BeforeConsent bool      `gorm:"default:false" json:"before_consent"` // Loaded before the consent banner
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the ThirdParty model
+func (ThirdParty) TableName() string {
+	return "third_parties"
+}
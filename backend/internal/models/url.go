@@ -13,8 +13,14 @@ const (
 	StatusProcessing URLStatus = "processing"
 	StatusCompleted  URLStatus = "completed"
 	StatusError      URLStatus = "error"
+
+	// StatusAssertionFailed marks a URL crawled successfully that failed one of its assertions
+	StatusAssertionFailed URLStatus = "assertion_failed"
 )
 
+// CrawledStatuses are the statuses of URLs whose page was crawled and analyzed successfully
+var CrawledStatuses = []URLStatus{StatusCompleted, StatusAssertionFailed}
+
 type URL struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
 	UserID    uint           `gorm:"not null;index" json:"user_id"`
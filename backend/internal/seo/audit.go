@@ -0,0 +1,135 @@
+package seo
+
+import (
+	"sort"
+
+	"web-crawler-dashboard/internal/crawler"
+)
+
+// Severity describes how serious a finding is
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Finding is a single problem reported by a rule
+type Finding struct {
+	RuleID   string
+	Severity Severity
+	Message  string
+}
+
+// Rule is an on-page SEO check run against a parsed page
+type Rule struct {
+	ID          string
+	Name        string
+	Description string
+	Weight      int // Share of the score this rule accounts for
+	Check       func(page *crawler.ParseResult) []Finding
+}
+
+// Config controls which rules are run
+type Config struct {
+	Disabled map[string]bool
+}
+
+// Report contains the findings and score of an audit
+type Report struct {
+	Score    int // 0-100, share of rule weight that passed
+	Findings []Finding
+	Rules    []string // IDs of the rules that were run
+}
+
+// NewConfig creates a configuration with the given rules disabled
+func NewConfig(disabledRules []string) *Config {
+	config := &Config{Disabled: make(map[string]bool)}
+	for _, ruleID := range disabledRules {
+		config.Disabled[ruleID] = true
+	}
+	return config
+}
+
+// IsEnabled reports whether a rule should run
+func (c *Config) IsEnabled(ruleID string) bool {
+	return c == nil || !c.Disabled[ruleID]
+}
+
+// Audit runs all enabled rules against a parsed page
+func Audit(page *crawler.ParseResult, config *Config) *Report {
+	return AuditWithRules(page, DefaultRules(), config)
+}
+
+// AuditWithRules runs the enabled subset of rules against a parsed page
+func AuditWithRules(page *crawler.ParseResult, rules []Rule, config *Config) *Report {
+	report := &Report{
+		Score:    100,
+		Findings: []Finding{},
+		Rules:    []string{},
+	}
+	if page == nil {
+		return report
+	}
+
+	totalWeight := 0
+	passedWeight := 0
+
+	for _, rule := range rules {
+		if !config.IsEnabled(rule.ID) {
+			continue
+		}
+
+		report.Rules = append(report.Rules, rule.ID)
+		totalWeight += rule.Weight
+
+		findings := rule.Check(page)
+		failed := false
+		for _, finding := range findings {
+			finding.RuleID = rule.ID
+			report.Findings = append(report.Findings, finding)
+			// Informational findings don't cost points
+			if finding.Severity != SeverityInfo {
+				failed = true
+			}
+		}
+
+		if !failed {
+			passedWeight += rule.Weight
+		}
+	}
+
+	if totalWeight > 0 {
+		report.Score = passedWeight * 100 / totalWeight
+	}
+
+	// Most severe findings first
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return severityRank(report.Findings[i].Severity) > severityRank(report.Findings[j].Severity)
+	})
+
+	return report
+}
+
+// FindRule looks up a default rule by ID
+func FindRule(ruleID string) (Rule, bool) {
+	for _, rule := range DefaultRules() {
+		if rule.ID == ruleID {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// severityRank orders severities for sorting
+func severityRank(severity Severity) int {
+	switch severity {
+	case SeverityError:
+		return 2
+	case SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
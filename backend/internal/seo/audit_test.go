@@ -0,0 +1,101 @@
+package seo
+
+import (
+	"strings"
+	"testing"
+
+	"web-crawler-dashboard/internal/crawler"
+)
+
+func TestAudit(t *testing.T) {
+	sampleHTML := `
+<html>
+<head>
+    <title>Short</title>
+    <meta name="description" content="First description">
+    <meta name="description" content="Second description">
+</head>
+<body>
+    <h1>One</h1>
+    <h1>Two</h1>
+    <h2>Section</h2>
+    <h4>Deep section</h4>
+    <img src="/a.png">
+    <img src="/b.png" alt="">
+</body>
+</html>`
+
+	page, err := crawler.ParseHTML(strings.NewReader(sampleHTML), "https://example.com")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	report := Audit(page, nil)
+
+	failed := make(map[string]bool)
+	for _, finding := range report.Findings {
+		if finding.Severity != SeverityInfo {
+			failed[finding.RuleID] = true
+		}
+	}
+
+	for _, ruleID := range []string{RuleTitleLength, RuleMetaDescription, RuleH1Count, RuleHeadingOrder, RuleImageAlt, RuleThinContent, RuleViewport} {
+		if !failed[ruleID] {
+			t.Errorf("Expected rule %s to fail", ruleID)
+		}
+	}
+
+	if report.Score != 0 {
+		t.Errorf("Expected score 0 when every rule fails, got %d", report.Score)
+	}
+
+	if report.Findings[0].Severity != SeverityError {
+		t.Errorf("Expected errors to be sorted first, got %s", report.Findings[0].Severity)
+	}
+}
+
+func TestAuditDisabledRules(t *testing.T) {
+	page := &crawler.ParseResult{
+		Title:                "A page title that is comfortably long enough",
+		MetaTags:             map[string]string{"viewport": "width=device-width"},
+		HeadingCounts:        map[string]int{"h1": 1},
+		Content:              crawler.ContentMetrics{WordCount: 500},
+		MetaDescriptionCount: 0,
+	}
+
+	report := Audit(page, nil)
+	if report.Score != 85 {
+		t.Errorf("Expected score 85 with only meta description failing, got %d", report.Score)
+	}
+
+	report = Audit(page, NewConfig([]string{RuleMetaDescription}))
+	if report.Score != 100 {
+		t.Errorf("Expected score 100 with meta description rule disabled, got %d", report.Score)
+	}
+	for _, ruleID := range report.Rules {
+		if ruleID == RuleMetaDescription {
+			t.Error("Disabled rule should not be run")
+		}
+	}
+}
+
+func TestImageAltCoverageUsesInventory(t *testing.T) {
+	page := &crawler.ParseResult{
+		Images: []crawler.ImageInfo{
+			{URL: "https://example.com/a.png", Element: "img", HasAlt: true},
+			{URL: "https://example.com/b.png", Element: "img", HasAlt: true},
+			{URL: "https://example.com/c.png", Element: "img", HasAlt: false},
+			{URL: "https://example.com/c.webp", Element: "source", HasAlt: false},
+		},
+	}
+
+	findings := checkImageAlt(page)
+	if len(findings) != 1 || findings[0].Message != "1 of 3 images have no alt attribute (67% coverage)" {
+		t.Errorf("Expected 1 of 3 images without alt, got %+v", findings)
+	}
+
+	page.Images[2].HasAlt = true
+	if findings := checkImageAlt(page); len(findings) != 0 {
+		t.Errorf("Expected no findings when every image has alt, got %+v", findings)
+	}
+}
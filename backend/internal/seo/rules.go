@@ -0,0 +1,200 @@
+package seo
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"web-crawler-dashboard/internal/crawler"
+)
+
+// Thresholds used by the default rules
+const (
+	MinTitleLength           = 30
+	MaxTitleLength           = 60
+	MinMetaDescriptionLength = 70
+	MaxMetaDescriptionLength = 160
+	MinAltCoverage           = 0.9
+	MinWordCount             = 300
+)
+
+// Rule IDs of the default rule set
+const (
+	RuleTitleLength     = "title-length"
+	RuleMetaDescription = "meta-description"
+	RuleH1Count         = "h1-count"
+	RuleHeadingOrder    = "heading-order"
+	RuleImageAlt        = "image-alt"
+	RuleThinContent     = "thin-content"
+	RuleViewport        = "viewport"
+)
+
+// DefaultRules returns the built-in on-page SEO rule set
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			ID:          RuleTitleLength,
+			Name:        "Title length",
+			Description: fmt.Sprintf("Page title should be between %d and %d characters", MinTitleLength, MaxTitleLength),
+			Weight:      15,
+			Check:       checkTitleLength,
+		},
+		{
+			ID:          RuleMetaDescription,
+			Name:        "Meta description",
+			Description: "Page should have exactly one meta description of reasonable length",
+			Weight:      15,
+			Check:       checkMetaDescription,
+		},
+		{
+			ID:          RuleH1Count,
+			Name:        "H1 count",
+			Description: "Page should have exactly one H1 heading",
+			Weight:      15,
+			Check:       checkH1Count,
+		},
+		{
+			ID:          RuleHeadingOrder,
+			Name:        "Heading order",
+			Description: "Heading levels should not be skipped (e.g. H2 followed by H4)",
+			Weight:      10,
+			Check:       checkHeadingOrder,
+		},
+		{
+			ID:          RuleImageAlt,
+			Name:        "Image alt coverage",
+			Description: fmt.Sprintf("At least %.0f%% of images should have an alt attribute", MinAltCoverage*100),
+			Weight:      15,
+			Check:       checkImageAlt,
+		},
+		{
+			ID:          RuleThinContent,
+			Name:        "Thin content",
+			Description: fmt.Sprintf("Main content (without navigation, header and footer) should contain at least %d words", MinWordCount),
+			Weight:      15,
+			Check:       checkThinContent,
+		},
+		{
+			ID:          RuleViewport,
+			Name:        "Viewport",
+			Description: "Page should declare a viewport meta tag for mobile devices",
+			Weight:      15,
+			Check:       checkViewport,
+		},
+	}
+}
+
+// checkTitleLength checks the page title is present and within length limits
+func checkTitleLength(page *crawler.ParseResult) []Finding {
+	length := utf8.RuneCountInString(strings.TrimSpace(page.Title))
+	switch {
+	case length == 0:
+		return []Finding{{Severity: SeverityError, Message: "Page has no title"}}
+	case length < MinTitleLength:
+		return []Finding{{Severity: SeverityWarning, Message: fmt.Sprintf("Title is too short (%d characters, minimum %d)", length, MinTitleLength)}}
+	case length > MaxTitleLength:
+		return []Finding{{Severity: SeverityWarning, Message: fmt.Sprintf("Title is too long (%d characters, maximum %d)", length, MaxTitleLength)}}
+	}
+	return nil
+}
+
+// checkMetaDescription checks for a missing, duplicate or badly sized meta description
+func checkMetaDescription(page *crawler.ParseResult) []Finding {
+	if page.MetaDescriptionCount == 0 || strings.TrimSpace(page.MetaTags["description"]) == "" {
+		return []Finding{{Severity: SeverityError, Message: "Meta description is missing"}}
+	}
+
+	var findings []Finding
+	if page.MetaDescriptionCount > 1 {
+		findings = append(findings, Finding{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("Page declares %d meta descriptions", page.MetaDescriptionCount),
+		})
+	}
+
+	length := utf8.RuneCountInString(strings.TrimSpace(page.MetaTags["description"]))
+	if length < MinMetaDescriptionLength || length > MaxMetaDescriptionLength {
+		findings = append(findings, Finding{
+			Severity: SeverityInfo,
+			Message: fmt.Sprintf("Meta description is %d characters, recommended %d-%d",
+				length, MinMetaDescriptionLength, MaxMetaDescriptionLength),
+		})
+	}
+
+	return findings
+}
+
+// checkH1Count checks the page has exactly one H1
+func checkH1Count(page *crawler.ParseResult) []Finding {
+	count := page.HeadingCounts["h1"]
+	switch {
+	case count == 0:
+		return []Finding{{Severity: SeverityError, Message: "Page has no H1 heading"}}
+	case count > 1:
+		return []Finding{{Severity: SeverityWarning, Message: fmt.Sprintf("Page has %d H1 headings", count)}}
+	}
+	return nil
+}
+
+// checkHeadingOrder reports every place where a heading level is skipped
+func checkHeadingOrder(page *crawler.ParseResult) []Finding {
+	var findings []Finding
+
+	for _, issue := range page.Outline.Issues {
+		if issue.Type == crawler.OutlineIssueSkippedLevel {
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Message:  issue.Message,
+			})
+		}
+	}
+
+	return findings
+}
+
+// checkImageAlt checks the share of images that have an alt attribute. <picture> sources
+// are left out since they use the alt text of their <img>.
+func checkImageAlt(page *crawler.ParseResult) []Finding {
+	total, withoutAlt := 0, 0
+	for _, image := range page.Images {
+		if image.Element != "img" {
+			continue
+		}
+		total++
+		if !image.HasAlt {
+			withoutAlt++
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	coverage := float64(total-withoutAlt) / float64(total)
+	if coverage < MinAltCoverage {
+		return []Finding{{
+			Severity: SeverityWarning,
+			Message: fmt.Sprintf("%d of %d images have no alt attribute (%.0f%% coverage)",
+				withoutAlt, total, coverage*100),
+		}}
+	}
+	return nil
+}
+
+// checkThinContent checks the page has enough text
+func checkThinContent(page *crawler.ParseResult) []Finding {
+	if page.Content.WordCount < MinWordCount {
+		return []Finding{{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("Main content contains only %d words (minimum %d)", page.Content.WordCount, MinWordCount),
+		}}
+	}
+	return nil
+}
+
+// checkViewport checks for a viewport meta tag
+func checkViewport(page *crawler.ParseResult) []Finding {
+	if strings.TrimSpace(page.MetaTags["viewport"]) == "" {
+		return []Finding{{Severity: SeverityError, Message: "Viewport meta tag is missing"}}
+	}
+	return nil
+}
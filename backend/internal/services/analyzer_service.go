@@ -0,0 +1,91 @@
+package services
+
+import (
+	"fmt"
+
+	"web-crawler-dashboard/internal/crawler"
+	"web-crawler-dashboard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AnalyzerStatus describes a registered page analyzer and whether it is enabled for a user
+type AnalyzerStatus struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// AnalyzerService manages which page analyzers run for a user
+type AnalyzerService struct {
+	db *gorm.DB
+}
+
+// NewAnalyzerService creates a new analyzer service
+func NewAnalyzerService(db *gorm.DB) *AnalyzerService {
+	return &AnalyzerService{db: db}
+}
+
+// ListAnalyzers returns every registered page analyzer with its enabled state for the user
+func (s *AnalyzerService) ListAnalyzers(userID uint) ([]AnalyzerStatus, error) {
+	disabled, err := loadDisabledAnalyzers(s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	analyzers := []AnalyzerStatus{}
+	for _, analyzer := range crawler.Analyzers() {
+		analyzers = append(analyzers, AnalyzerStatus{
+			ID:          analyzer.ID(),
+			Name:        analyzer.Name(),
+			Description: analyzer.Description(),
+			Enabled:     !disabled[analyzer.ID()],
+		})
+	}
+
+	return analyzers, nil
+}
+
+// UpdateAnalyzers enables or disables page analyzers for the user
+func (s *AnalyzerService) UpdateAnalyzers(userID uint, analyzers map[string]bool) error {
+	for analyzerID := range analyzers {
+		if _, exists := crawler.FindAnalyzer(analyzerID); !exists {
+			return fmt.Errorf("unknown analyzer: %s", analyzerID)
+		}
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		for analyzerID, enabled := range analyzers {
+			var setting models.AnalyzerSetting
+			err := tx.Where("user_id = ? AND analyzer_id = ?", userID, analyzerID).First(&setting).Error
+			if err != nil && err != gorm.ErrRecordNotFound {
+				return fmt.Errorf("failed to load analyzer setting: %w", err)
+			}
+
+			setting.UserID = userID
+			setting.AnalyzerID = analyzerID
+			setting.Enabled = enabled
+			if err := tx.Save(&setting).Error; err != nil {
+				return fmt.Errorf("failed to save analyzer setting: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// loadDisabledAnalyzers returns the IDs of the page analyzers the user has disabled
+func loadDisabledAnalyzers(db *gorm.DB, userID uint) (map[string]bool, error) {
+	var ids []string
+	if err := db.Model(&models.AnalyzerSetting{}).
+		Where("user_id = ? AND enabled = ?", userID, false).
+		Pluck("analyzer_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to load analyzer settings: %w", err)
+	}
+
+	disabled := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		disabled[id] = true
+	}
+	return disabled, nil
+}
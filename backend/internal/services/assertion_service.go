@@ -0,0 +1,172 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"web-crawler-dashboard/internal/crawler"
+	"web-crawler-dashboard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Limits on the assertion runs returned per request
+const (
+	defaultAssertionRuns = 20
+	maxAssertionRuns     = 100
+)
+
+// AssertionService manages the assertions checked after each crawl of a URL and their runs
+type AssertionService struct {
+	db *gorm.DB
+}
+
+// NewAssertionService creates a new assertion service
+func NewAssertionService(db *gorm.DB) *AssertionService {
+	return &AssertionService{db: db}
+}
+
+// ListAssertions returns the assertions of one of the user's URLs
+func (s *AssertionService) ListAssertions(userID, urlID uint) ([]models.Assertion, error) {
+	if err := s.verifyURL(userID, urlID); err != nil {
+		return nil, err
+	}
+
+	var assertions []models.Assertion
+	if err := s.db.Where("url_id = ?", urlID).Order("id ASC").Find(&assertions).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve assertions: %w", err)
+	}
+
+	return assertions, nil
+}
+
+// CreateAssertion validates and stores a new assertion for a URL
+func (s *AssertionService) CreateAssertion(userID, urlID uint, assertionType, value string) (*models.Assertion, error) {
+	if err := s.verifyURL(userID, urlID); err != nil {
+		return nil, err
+	}
+
+	assertion := models.Assertion{UserID: userID, URLID: urlID}
+	if err := applyAssertion(&assertion, assertionType, value); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&assertion).Error; err != nil {
+		return nil, fmt.Errorf("failed to create assertion: %w", err)
+	}
+
+	return &assertion, nil
+}
+
+// UpdateAssertion validates and replaces an assertion of a URL
+func (s *AssertionService) UpdateAssertion(userID, urlID, assertionID uint, assertionType, value string) (*models.Assertion, error) {
+	assertion, err := s.getAssertion(userID, urlID, assertionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyAssertion(assertion, assertionType, value); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(assertion).Error; err != nil {
+		return nil, fmt.Errorf("failed to update assertion: %w", err)
+	}
+
+	return assertion, nil
+}
+
+// DeleteAssertion removes an assertion; its past results stay with their runs
+func (s *AssertionService) DeleteAssertion(userID, urlID, assertionID uint) error {
+	assertion, err := s.getAssertion(userID, urlID, assertionID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.Delete(assertion).Error; err != nil {
+		return fmt.Errorf("failed to delete assertion: %w", err)
+	}
+
+	return nil
+}
+
+// ListRuns returns the most recent assertion runs of a URL with their results, newest first
+func (s *AssertionService) ListRuns(userID, urlID uint, limit int) ([]models.AssertionRun, error) {
+	if err := s.verifyURL(userID, urlID); err != nil {
+		return nil, err
+	}
+	if limit < 1 {
+		limit = defaultAssertionRuns
+	}
+	if limit > maxAssertionRuns {
+		limit = maxAssertionRuns
+	}
+
+	runs := []models.AssertionRun{}
+	err := s.db.Preload("Results", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).
+		Where("url_id = ?", urlID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&runs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve assertion runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+// verifyURL checks that the URL belongs to the user
+func (s *AssertionService) verifyURL(userID, urlID uint) error {
+	var count int64
+	if err := s.db.Model(&models.URL{}).Where("id = ? AND user_id = ?", urlID, userID).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to verify URL: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("URL not found")
+	}
+	return nil
+}
+
+// getAssertion loads an assertion of one of the user's URLs
+func (s *AssertionService) getAssertion(userID, urlID, assertionID uint) (*models.Assertion, error) {
+	var assertion models.Assertion
+	if err := s.db.Where("id = ? AND url_id = ? AND user_id = ?", assertionID, urlID, userID).First(&assertion).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("assertion not found")
+		}
+		return nil, fmt.Errorf("failed to retrieve assertion: %w", err)
+	}
+	return &assertion, nil
+}
+
+// applyAssertion validates the type and value and copies them onto the assertion
+func applyAssertion(assertion *models.Assertion, assertionType, value string) error {
+	value = strings.TrimSpace(value)
+	if err := crawler.ValidateAssertion(crawler.Assertion{Type: assertionType, Value: value}); err != nil {
+		return err
+	}
+
+	assertion.Type = assertionType
+	assertion.Value = value
+	return nil
+}
+
+// loadAssertions returns the assertions checked after each crawl of a URL
+func loadAssertions(db *gorm.DB, urlID uint) ([]crawler.Assertion, error) {
+	var stored []models.Assertion
+	if err := db.Where("url_id = ?", urlID).Order("id ASC").Find(&stored).Error; err != nil {
+		return nil, fmt.Errorf("failed to load assertions: %w", err)
+	}
+
+	var assertions []crawler.Assertion
+	for _, assertion := range stored {
+		assertions = append(assertions, crawler.Assertion{
+			ID:    assertion.ID,
+			Type:  assertion.Type,
+			Value: assertion.Value,
+		})
+	}
+	return assertions, nil
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"web-crawler-dashboard/internal/crawler"
+	"web-crawler-dashboard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// DefaultDuplicateThreshold is the content similarity above which pages are near-duplicates
+const DefaultDuplicateThreshold = 0.85
+
+// DuplicatePage is an analyzed page in a duplicate group
+type DuplicatePage struct {
+	ID    uint   `json:"id"`
+	URL   string `json:"url"`
+	Title string `json:"title"`
+}
+
+// ContentCluster is a group of pages with near-duplicate main text
+type ContentCluster struct {
+	MinSimilarity float64         `json:"min_similarity"` // Lowest similarity between two pages of the cluster
+	Pages         []DuplicatePage `json:"pages"`
+}
+
+// DuplicateGroup is a group of pages sharing the exact same value, such as a title
+type DuplicateGroup struct {
+	Value string          `json:"value"`
+	Pages []DuplicatePage `json:"pages"`
+}
+
+// DuplicateReport lists near-duplicate content and duplicate titles and meta descriptions
+type DuplicateReport struct {
+	Threshold                 float64          `json:"threshold"`
+	ContentClusters           []ContentCluster `json:"content_clusters"`
+	DuplicateTitles           []DuplicateGroup `json:"duplicate_titles"`
+	DuplicateMetaDescriptions []DuplicateGroup `json:"duplicate_meta_descriptions"`
+}
+
+// duplicateCandidate is an analyzed page with the values compared across pages
+type duplicateCandidate struct {
+	ID              uint
+	URL             string
+	Title           string
+	MetaDescription string
+	ContentHash     uint64
+}
+
+// DuplicateService compares a user's analyzed pages with each other
+type DuplicateService struct {
+	db *gorm.DB
+}
+
+// NewDuplicateService creates a new duplicate service
+func NewDuplicateService(db *gorm.DB) *DuplicateService {
+	return &DuplicateService{db: db}
+}
+
+// FindDuplicates groups the user's analyzed pages into near-duplicate content clusters
+// at the given similarity threshold, and finds pages sharing a title or meta description
+func (s *DuplicateService) FindDuplicates(userID uint, threshold float64) (*DuplicateReport, error) {
+	var candidates []duplicateCandidate
+	err := s.db.Table("urls").
+		Select("urls.id, urls.url, analysis_results.title, analysis_results.meta_description, analysis_results.content_hash").
+		Joins("JOIN analysis_results ON analysis_results.url_id = urls.id AND analysis_results.deleted_at IS NULL").
+		Where("urls.user_id = ? AND urls.status IN ? AND urls.deleted_at IS NULL", userID, models.CrawledStatuses).
+		Order("urls.url ASC").
+		Scan(&candidates).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve analyzed pages: %w", err)
+	}
+
+	return &DuplicateReport{
+		Threshold:       threshold,
+		ContentClusters: clusterByContent(candidates, threshold),
+		DuplicateTitles: groupByValue(candidates, func(c duplicateCandidate) string {
+			return c.Title
+		}),
+		DuplicateMetaDescriptions: groupByValue(candidates, func(c duplicateCandidate) string {
+			return c.MetaDescription
+		}),
+	}, nil
+}
+
+// clusterByContent links every pair of pages at or above the threshold and returns the
+// connected groups, largest first
+func clusterByContent(candidates []duplicateCandidate, threshold float64) []ContentCluster {
+	parent := make([]int, len(candidates))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i := range candidates {
+		if candidates[i].ContentHash == 0 {
+			continue
+		}
+		for j := i + 1; j < len(candidates); j++ {
+			if candidates[j].ContentHash == 0 {
+				continue
+			}
+			if crawler.SimHashSimilarity(candidates[i].ContentHash, candidates[j].ContentHash) >= threshold {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+	var roots []int
+	for i := range candidates {
+		root := find(i)
+		if _, exists := members[root]; !exists {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], i)
+	}
+
+	clusters := []ContentCluster{}
+	for _, root := range roots {
+		indexes := members[root]
+		if len(indexes) < 2 {
+			continue
+		}
+
+		cluster := ContentCluster{MinSimilarity: 1}
+		for a, i := range indexes {
+			cluster.Pages = append(cluster.Pages, toDuplicatePage(candidates[i]))
+			for _, j := range indexes[a+1:] {
+				similarity := crawler.SimHashSimilarity(candidates[i].ContentHash, candidates[j].ContentHash)
+				if similarity < cluster.MinSimilarity {
+					cluster.MinSimilarity = similarity
+				}
+			}
+		}
+		clusters = append(clusters, cluster)
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return len(clusters[i].Pages) > len(clusters[j].Pages)
+	})
+	return clusters
+}
+
+// groupByValue returns the groups of pages sharing the same non-empty value, largest first
+func groupByValue(candidates []duplicateCandidate, value func(duplicateCandidate) string) []DuplicateGroup {
+	pagesByValue := make(map[string][]DuplicatePage)
+	var values []string
+	for _, candidate := range candidates {
+		v := strings.TrimSpace(value(candidate))
+		if v == "" {
+			continue
+		}
+		if _, exists := pagesByValue[v]; !exists {
+			values = append(values, v)
+		}
+		pagesByValue[v] = append(pagesByValue[v], toDuplicatePage(candidate))
+	}
+
+	groups := []DuplicateGroup{}
+	for _, v := range values {
+		if len(pagesByValue[v]) > 1 {
+			groups = append(groups, DuplicateGroup{Value: v, Pages: pagesByValue[v]})
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return len(groups[i].Pages) > len(groups[j].Pages)
+	})
+	return groups
+}
+
+// toDuplicatePage converts a candidate to its reported form
+func toDuplicatePage(candidate duplicateCandidate) DuplicatePage {
+	return DuplicatePage{ID: candidate.ID, URL: candidate.URL, Title: candidate.Title}
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"fmt"
+
+	"web-crawler-dashboard/internal/crawler"
+	"web-crawler-dashboard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ExtractionRuleInput holds the fields of an extraction rule set by the user
+type ExtractionRuleInput struct {
+	URLID       *uint
+	Name        string
+	Type        string
+	Expression  string
+	Attribute   string
+	Mode        string
+	HostSuffix  string
+	PathPattern string
+}
+
+// ExtractionService manages the rules that read values such as prices from analyzed pages
+type ExtractionService struct {
+	db *gorm.DB
+}
+
+// NewExtractionService creates a new extraction service
+func NewExtractionService(db *gorm.DB) *ExtractionService {
+	return &ExtractionService{db: db}
+}
+
+// ListRules returns the user's rules, optionally only those for a single URL
+func (s *ExtractionService) ListRules(userID uint, urlID *uint) ([]models.ExtractionRule, error) {
+	query := s.db.Where("user_id = ?", userID)
+	if urlID != nil {
+		query = query.Where("url_id = ?", *urlID)
+	}
+
+	var rules []models.ExtractionRule
+	if err := query.Order("id ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve extraction rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// CreateRule validates and stores a new rule
+func (s *ExtractionService) CreateRule(userID uint, input ExtractionRuleInput) (*models.ExtractionRule, error) {
+	rule := models.ExtractionRule{UserID: userID}
+	if err := s.applyInput(&rule, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&rule).Error; err != nil {
+		return nil, fmt.Errorf("failed to create extraction rule: %w", err)
+	}
+
+	return &rule, nil
+}
+
+// UpdateRule validates and replaces an existing rule
+func (s *ExtractionService) UpdateRule(userID, ruleID uint, input ExtractionRuleInput) (*models.ExtractionRule, error) {
+	rule, err := s.getRule(userID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyInput(rule, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(rule).Error; err != nil {
+		return nil, fmt.Errorf("failed to update extraction rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// DeleteRule removes a rule; values already extracted stay with their analyses
+func (s *ExtractionService) DeleteRule(userID, ruleID uint) error {
+	rule, err := s.getRule(userID, ruleID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.Delete(rule).Error; err != nil {
+		return fmt.Errorf("failed to delete extraction rule: %w", err)
+	}
+
+	return nil
+}
+
+// getRule loads a rule owned by the user
+func (s *ExtractionService) getRule(userID, ruleID uint) (*models.ExtractionRule, error) {
+	var rule models.ExtractionRule
+	if err := s.db.Where("id = ? AND user_id = ?", ruleID, userID).First(&rule).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("extraction rule not found")
+		}
+		return nil, fmt.Errorf("failed to retrieve extraction rule: %w", err)
+	}
+	return &rule, nil
+}
+
+// applyInput validates the input and copies it onto the rule
+func (s *ExtractionService) applyInput(rule *models.ExtractionRule, input ExtractionRuleInput) error {
+	if input.Mode == "" {
+		input.Mode = crawler.ExtractSingle
+	}
+
+	if err := crawler.ValidateExtractionRule(toCrawlerExtractionRule(models.ExtractionRule{
+		Name:        input.Name,
+		Type:        input.Type,
+		Expression:  input.Expression,
+		Attribute:   input.Attribute,
+		Mode:        input.Mode,
+		HostSuffix:  input.HostSuffix,
+		PathPattern: input.PathPattern,
+	})); err != nil {
+		return err
+	}
+
+	// URL-specific rules must belong to one of the user's URLs
+	if input.URLID != nil {
+		var count int64
+		if err := s.db.Model(&models.URL{}).Where("id = ? AND user_id = ?", *input.URLID, rule.UserID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to verify URL: %w", err)
+		}
+		if count == 0 {
+			return fmt.Errorf("URL not found")
+		}
+	}
+
+	rule.URLID = input.URLID
+	rule.Name = input.Name
+	rule.Type = input.Type
+	rule.Expression = input.Expression
+	rule.Attribute = input.Attribute
+	rule.Mode = input.Mode
+	rule.HostSuffix = input.HostSuffix
+	rule.PathPattern = input.PathPattern
+
+	return nil
+}
+
+// loadExtractionRules returns the rules for a URL: its own rules and the user's general rules
+func loadExtractionRules(db *gorm.DB, userID, urlID uint) ([]crawler.ExtractionRule, error) {
+	var stored []models.ExtractionRule
+	if err := db.Where("user_id = ? AND (url_id = ? OR url_id IS NULL)", userID, urlID).
+		Order("id ASC").
+		Find(&stored).Error; err != nil {
+		return nil, fmt.Errorf("failed to load extraction rules: %w", err)
+	}
+
+	var rules []crawler.ExtractionRule
+	for _, rule := range stored {
+		rules = append(rules, toCrawlerExtractionRule(rule))
+	}
+	return rules, nil
+}
+
+// toCrawlerExtractionRule converts a stored rule to the crawler representation
+func toCrawlerExtractionRule(rule models.ExtractionRule) crawler.ExtractionRule {
+	return crawler.ExtractionRule{
+		ID:          rule.ID,
+		Name:        rule.Name,
+		Type:        rule.Type,
+		Expression:  rule.Expression,
+		Attribute:   rule.Attribute,
+		Mode:        rule.Mode,
+		HostSuffix:  rule.HostSuffix,
+		PathPattern: rule.PathPattern,
+	}
+}
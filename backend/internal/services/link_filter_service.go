@@ -0,0 +1,169 @@
+package services
+
+import (
+	"fmt"
+
+	"web-crawler-dashboard/internal/crawler"
+	"web-crawler-dashboard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// LinkFilterRuleInput holds the fields of a link filter rule set by the user
+type LinkFilterRuleInput struct {
+	URLID       *uint
+	Action      string
+	HostSuffix  string
+	PathPattern string
+	PatternType string
+	Reason      string
+}
+
+// LinkFilterService manages the include/exclude rules of the link checker
+type LinkFilterService struct {
+	db *gorm.DB
+}
+
+// NewLinkFilterService creates a new link filter service
+func NewLinkFilterService(db *gorm.DB) *LinkFilterService {
+	return &LinkFilterService{db: db}
+}
+
+// ListRules returns the user's rules, optionally only those for a single URL
+func (s *LinkFilterService) ListRules(userID uint, urlID *uint) ([]models.LinkFilterRule, error) {
+	query := s.db.Where("user_id = ?", userID)
+	if urlID != nil {
+		query = query.Where("url_id = ?", *urlID)
+	}
+
+	var rules []models.LinkFilterRule
+	if err := query.Order("id ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve link filter rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// CreateRule validates and stores a new rule
+func (s *LinkFilterService) CreateRule(userID uint, input LinkFilterRuleInput) (*models.LinkFilterRule, error) {
+	rule := models.LinkFilterRule{UserID: userID}
+	if err := s.applyInput(&rule, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&rule).Error; err != nil {
+		return nil, fmt.Errorf("failed to create link filter rule: %w", err)
+	}
+
+	return &rule, nil
+}
+
+// UpdateRule validates and replaces an existing rule
+func (s *LinkFilterService) UpdateRule(userID, ruleID uint, input LinkFilterRuleInput) (*models.LinkFilterRule, error) {
+	rule, err := s.getRule(userID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyInput(rule, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(rule).Error; err != nil {
+		return nil, fmt.Errorf("failed to update link filter rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// DeleteRule removes a rule
+func (s *LinkFilterService) DeleteRule(userID, ruleID uint) error {
+	rule, err := s.getRule(userID, ruleID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.Delete(rule).Error; err != nil {
+		return fmt.Errorf("failed to delete link filter rule: %w", err)
+	}
+
+	return nil
+}
+
+// getRule loads a rule owned by the user
+func (s *LinkFilterService) getRule(userID, ruleID uint) (*models.LinkFilterRule, error) {
+	var rule models.LinkFilterRule
+	if err := s.db.Where("id = ? AND user_id = ?", ruleID, userID).First(&rule).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("link filter rule not found")
+		}
+		return nil, fmt.Errorf("failed to retrieve link filter rule: %w", err)
+	}
+	return &rule, nil
+}
+
+// applyInput validates the input and copies it onto the rule
+func (s *LinkFilterService) applyInput(rule *models.LinkFilterRule, input LinkFilterRuleInput) error {
+	if input.PatternType == "" {
+		input.PatternType = crawler.PatternGlob
+	}
+
+	if err := crawler.ValidateLinkFilterRule(toCrawlerFilterRule(models.LinkFilterRule{
+		Action:      input.Action,
+		HostSuffix:  input.HostSuffix,
+		PathPattern: input.PathPattern,
+		PatternType: input.PatternType,
+	})); err != nil {
+		return err
+	}
+
+	// URL-specific rules must belong to one of the user's URLs
+	if input.URLID != nil {
+		var count int64
+		if err := s.db.Model(&models.URL{}).Where("id = ? AND user_id = ?", *input.URLID, rule.UserID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to verify URL: %w", err)
+		}
+		if count == 0 {
+			return fmt.Errorf("URL not found")
+		}
+	}
+
+	rule.URLID = input.URLID
+	rule.Action = input.Action
+	rule.HostSuffix = input.HostSuffix
+	rule.PathPattern = input.PathPattern
+	rule.PatternType = input.PatternType
+	rule.Reason = input.Reason
+
+	return nil
+}
+
+// loadLinkFilter builds the link filter for a URL: URL rules first, then the
+// user's general rules, then the built-in defaults
+func loadLinkFilter(db *gorm.DB, userID, urlID uint) (*crawler.LinkFilter, error) {
+	var stored []models.LinkFilterRule
+	if err := db.Where("user_id = ? AND (url_id = ? OR url_id IS NULL)", userID, urlID).
+		Order("url_id IS NULL, id ASC").
+		Find(&stored).Error; err != nil {
+		return nil, fmt.Errorf("failed to load link filter rules: %w", err)
+	}
+
+	var rules []crawler.LinkFilterRule
+	for _, rule := range stored {
+		rules = append(rules, toCrawlerFilterRule(rule))
+	}
+	rules = append(rules, crawler.DefaultLinkFilterRules()...)
+
+	return crawler.NewLinkFilter(rules)
+}
+
+// toCrawlerFilterRule converts a stored rule to the crawler representation
+func toCrawlerFilterRule(rule models.LinkFilterRule) crawler.LinkFilterRule {
+	return crawler.LinkFilterRule{
+		Action:      rule.Action,
+		HostSuffix:  rule.HostSuffix,
+		PathPattern: rule.PathPattern,
+		PatternType: rule.PatternType,
+		Reason:      rule.Reason,
+	}
+}
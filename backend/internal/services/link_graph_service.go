@@ -0,0 +1,288 @@
+package services
+
+import (
+	"fmt"
+	neturl "net/url"
+	"strings"
+
+	"web-crawler-dashboard/internal/crawler"
+	"web-crawler-dashboard/internal/graph"
+	"web-crawler-dashboard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// maxInboundLinks limits how many inbound links are returned for a single target
+const maxInboundLinks = 1000
+
+// InboundLink is a link from one of the user's analyzed pages to a target URL
+type InboundLink struct {
+	SourceURLID uint   `json:"source_url_id"`
+	SourceURL   string `json:"source_url"`
+	TargetURL   string `json:"target_url"`
+	AnchorText  string `json:"anchor_text"`
+	NoFollow    bool   `json:"nofollow"`
+	Sponsored   bool   `json:"sponsored"`
+	UGC         bool   `json:"ugc"`
+	Internal    bool   `json:"internal"`
+}
+
+// LinkTarget is a link target with the number of links and linking pages pointing at it
+type LinkTarget struct {
+	TargetURL string `json:"target_url"`
+	Links     int64  `json:"links"`
+	Pages     int64  `json:"pages"`
+}
+
+// LinkGraphService answers link structure questions across the user's analyzed pages
+type LinkGraphService struct {
+	db *gorm.DB
+}
+
+// NewLinkGraphService creates a new link graph service
+func NewLinkGraphService(db *gorm.DB) *LinkGraphService {
+	return &LinkGraphService{db: db}
+}
+
+// GetOutboundLinks returns the links found on a URL's latest analysis
+func (s *LinkGraphService) GetOutboundLinks(userID, urlID uint) ([]models.LinkEdge, error) {
+	if _, err := s.getURL(userID, urlID); err != nil {
+		return nil, err
+	}
+
+	var edges []models.LinkEdge
+	if err := s.db.Where("url_id = ?", urlID).Order("id ASC").Find(&edges).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve outbound links: %w", err)
+	}
+
+	return edges, nil
+}
+
+// GetInboundLinksForURL returns the links from the user's pages pointing at one of their URLs
+func (s *LinkGraphService) GetInboundLinksForURL(userID, urlID uint) ([]InboundLink, error) {
+	url, err := s.getURL(userID, urlID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetInboundLinks(userID, url.URL)
+}
+
+// GetInboundLinks returns the links from the user's pages pointing at a target URL,
+// e.g. to find the pages linking to a broken URL
+func (s *LinkGraphService) GetInboundLinks(userID uint, targetURL string) ([]InboundLink, error) {
+	links := []InboundLink{}
+	err := s.db.Table("link_edges").
+		Select("link_edges.url_id AS source_url_id, urls.url AS source_url, link_edges.target_url, link_edges.anchor_text, "+
+			"link_edges.no_follow, link_edges.sponsored, link_edges.ugc, link_edges.internal").
+		Joins("JOIN urls ON urls.id = link_edges.url_id").
+		Where("urls.user_id = ? AND urls.deleted_at IS NULL", userID).
+		Where("link_edges.target_url IN ?", crawler.LinkTargetVariants(targetURL)).
+		Order("link_edges.url_id ASC, link_edges.id ASC").
+		Limit(maxInboundLinks).
+		Scan(&links).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve inbound links: %w", err)
+	}
+
+	return links, nil
+}
+
+// GetTopTargets returns the most linked targets across the user's pages
+func (s *LinkGraphService) GetTopTargets(userID uint, limit int, internalOnly bool) ([]LinkTarget, error) {
+	query := s.db.Table("link_edges").
+		Select("link_edges.target_url, COUNT(*) AS links, COUNT(DISTINCT link_edges.url_id) AS pages").
+		Joins("JOIN urls ON urls.id = link_edges.url_id").
+		Where("urls.user_id = ? AND urls.deleted_at IS NULL", userID)
+	if internalOnly {
+		query = query.Where("link_edges.internal = ?", true)
+	}
+
+	targets := []LinkTarget{}
+	err := query.Group("link_edges.target_url").
+		Order("pages DESC, links DESC, link_edges.target_url ASC").
+		Limit(limit).
+		Scan(&targets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve top link targets: %w", err)
+	}
+
+	return targets, nil
+}
+
+// GetOrphanPages returns the user's analyzed URLs that none of their other analyzed pages link to.
+// The result is only meaningful when the user's URLs cover a site crawl.
+func (s *LinkGraphService) GetOrphanPages(userID uint) ([]models.URL, error) {
+	var pages []models.URL
+	if err := s.db.Where("user_id = ? AND status IN ?", userID, models.CrawledStatuses).Order("url ASC").Find(&pages).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve URLs: %w", err)
+	}
+
+	orphans := []models.URL{}
+	if len(pages) == 0 {
+		return orphans, nil
+	}
+
+	// Map every form a page can take as a link target back to the page
+	pageByTarget := make(map[string]uint)
+	var targets []string
+	for _, page := range pages {
+		for _, variant := range crawler.LinkTargetVariants(page.URL) {
+			pageByTarget[variant] = page.ID
+			targets = append(targets, variant)
+		}
+	}
+
+	var edges []struct {
+		URLID     uint
+		TargetURL string
+	}
+	err := s.db.Table("link_edges").
+		Select("DISTINCT link_edges.url_id, link_edges.target_url").
+		Joins("JOIN urls ON urls.id = link_edges.url_id").
+		Where("urls.user_id = ? AND urls.deleted_at IS NULL", userID).
+		Where("link_edges.target_url IN ?", targets).
+		Scan(&edges).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve link edges: %w", err)
+	}
+
+	linked := make(map[uint]bool)
+	for _, edge := range edges {
+		// Links from a page to itself don't count
+		if pageID := pageByTarget[edge.TargetURL]; pageID != edge.URLID {
+			linked[pageID] = true
+		}
+	}
+
+	for _, page := range pages {
+		if !linked[page.ID] {
+			orphans = append(orphans, page)
+		}
+	}
+
+	return orphans, nil
+}
+
+// BuildGraph builds the link graph of a URL, or with siteScope of every analyzed page of
+// the user on the same host. Link targets that failed with a class counted by default are broken.
+func (s *LinkGraphService) BuildGraph(userID, urlID uint, siteScope bool) (*graph.Graph, error) {
+	url, err := s.getURL(userID, urlID)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := []models.URL{*url}
+	if siteScope {
+		var candidates []models.URL
+		if err := s.db.Where("user_id = ? AND status IN ?", userID, models.CrawledStatuses).Find(&candidates).Error; err != nil {
+			return nil, fmt.Errorf("failed to retrieve URLs: %w", err)
+		}
+
+		host := urlHost(url.URL)
+		for _, candidate := range candidates {
+			if candidate.ID != url.ID && urlHost(candidate.URL) == host {
+				pages = append(pages, candidate)
+			}
+		}
+	}
+
+	builder := graph.NewBuilder()
+	pageIDs := make([]uint, 0, len(pages))
+	pageURLs := make(map[uint]string, len(pages))
+	nodeByTarget := make(map[string]string) // Merges "https://example.com" and "https://example.com/"
+	for _, page := range pages {
+		variants := crawler.LinkTargetVariants(page.URL)
+		builder.AddPage(variants[0])
+		pageIDs = append(pageIDs, page.ID)
+		pageURLs[page.ID] = variants[0]
+		for _, variant := range variants {
+			nodeByTarget[variant] = variants[0]
+		}
+	}
+
+	var edges []models.LinkEdge
+	if err := s.db.Where("url_id IN ?", pageIDs).Order("id ASC").Find(&edges).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve link edges: %w", err)
+	}
+	for _, edge := range edges {
+		target := edge.TargetURL
+		if pageURL, exists := nodeByTarget[target]; exists {
+			target = pageURL
+		}
+		builder.AddLink(pageURLs[edge.URLID], target, edge.AnchorText, edge.NoFollow, edge.Internal)
+	}
+
+	// Each page counts the link classes from its own crawl settings, as its crawl did
+	var settings []models.CrawlSettings
+	if err := s.db.Where("url_id IN ?", pageIDs).Find(&settings).Error; err != nil {
+		return nil, fmt.Errorf("failed to load crawl settings: %w", err)
+	}
+	countedClasses := make(map[uint]map[string]bool, len(pageIDs))
+	for _, pageID := range pageIDs {
+		countedClasses[pageID] = classSet(crawler.DefaultCountedClasses())
+	}
+	for _, setting := range settings {
+		if setting.CountedClasses != nil {
+			countedClasses[setting.URLID] = classSet(setting.CountedClasses)
+		}
+	}
+
+	var brokenLinks []struct {
+		URLID uint
+		URL   string
+		Class string
+	}
+	err = s.db.Table("broken_links").
+		Select("DISTINCT analysis_results.url_id, broken_links.url, broken_links.class").
+		Joins("JOIN analysis_results ON analysis_results.id = broken_links.analysis_id").
+		Where("analysis_results.url_id IN ? AND broken_links.deleted_at IS NULL", pageIDs).
+		Where("broken_links.resource_type = ?", crawler.ResourceLink).
+		Scan(&brokenLinks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve broken links: %w", err)
+	}
+	for _, link := range brokenLinks {
+		if !countedClasses[link.URLID][link.Class] {
+			continue
+		}
+
+		brokenURL := link.URL
+		if pageURL, exists := nodeByTarget[brokenURL]; exists {
+			brokenURL = pageURL
+		}
+		builder.MarkBroken(brokenURL)
+	}
+
+	return builder.Build(), nil
+}
+
+// urlHost returns the lowercase host of a URL
+func urlHost(rawURL string) string {
+	parsedURL, err := neturl.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsedURL.Host)
+}
+
+// getURL returns a URL owned by the user
+func (s *LinkGraphService) getURL(userID, urlID uint) (*models.URL, error) {
+	var url models.URL
+	if err := s.db.Where("id = ? AND user_id = ?", urlID, userID).First(&url).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("URL not found")
+		}
+		return nil, fmt.Errorf("failed to retrieve URL: %w", err)
+	}
+	return &url, nil
+}
+
+// classSet turns a list of link classes into a set
+func classSet(classes []string) map[string]bool {
+	set := make(map[string]bool, len(classes))
+	for _, class := range classes {
+		set[class] = true
+	}
+	return set
+}
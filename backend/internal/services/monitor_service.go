@@ -0,0 +1,415 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"web-crawler-dashboard/internal/crawler"
+	"web-crawler-dashboard/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Monitoring intervals in seconds
+const (
+	defaultMonitorInterval = 60
+	minMonitorInterval     = 30
+	maxMonitorInterval     = 3600
+)
+
+// Scheduler settings
+const (
+	monitorTick          = 10 * time.Second // How often due monitors are looked up
+	maxConcurrentProbes  = 10
+	uptimeRetention      = 30 * 24 * time.Hour // Longest window reported
+	uptimePruneInterval  = time.Hour
+	defaultUptimeChecks  = 1440
+	maxUptimeChecks      = 10000
+	monitorProbeTimeout  = 10 * time.Second
+	monitorProbeRetries  = 1
+	monitorProbeRetryGap = time.Second
+)
+
+// uptimeWindows are the periods uptime is reported for
+var uptimeWindows = []struct {
+	Name     string
+	Duration time.Duration
+}{
+	{"24h", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+	{"30d", 30 * 24 * time.Hour},
+}
+
+// MonitorUpdate holds the monitoring settings to change; nil fields are left unchanged
+type MonitorUpdate struct {
+	Enabled         *bool
+	IntervalSeconds *int
+}
+
+// UptimeReport describes the availability of a URL over the last 24 hours, 7 and 30 days
+type UptimeReport struct {
+	Monitor   *models.Monitor                  `json:"monitor"`
+	LastCheck *models.UptimeCheck              `json:"last_check"` // Nil before the first probe
+	Windows   map[string]crawler.UptimeSummary `json:"windows"`
+}
+
+// MonitorService runs uptime probes for monitored URLs in the background and reports on them
+type MonitorService struct {
+	db             *gorm.DB
+	crawlerService *crawler.CrawlerService
+
+	mu       sync.Mutex
+	inFlight map[uint]bool // URL IDs being probed
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// NewMonitorService creates a new monitor service. Probes use a short timeout and a single
+// retry so that they finish well within the shortest interval, and share the host scheduler
+// and circuit breaker of the given crawler service with the crawls.
+func NewMonitorService(db *gorm.DB, crawlerService *crawler.CrawlerService) *MonitorService {
+	return &MonitorService{
+		db:             db,
+		crawlerService: crawlerService.WithRequestSettings(monitorProbeTimeout, monitorProbeRetries, monitorProbeRetryGap),
+		inFlight:       make(map[uint]bool),
+	}
+}
+
+// Start probes the monitored URLs in the background until Stop is called
+func (s *MonitorService) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(ctx)
+	}()
+	log.Println("Uptime monitor started")
+}
+
+// Stop cancels running probes and waits for the scheduler to finish
+func (s *MonitorService) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+	log.Println("Uptime monitor stopped")
+}
+
+// GetMonitor returns the monitoring settings of a URL, disabled by default
+func (s *MonitorService) GetMonitor(userID, urlID uint) (*models.Monitor, error) {
+	if err := s.verifyURL(userID, urlID); err != nil {
+		return nil, err
+	}
+
+	return s.findMonitor(urlID, userID)
+}
+
+// UpdateMonitor enables or disables monitoring of a URL and changes its interval
+func (s *MonitorService) UpdateMonitor(userID, urlID uint, update MonitorUpdate) (*models.Monitor, error) {
+	if err := s.verifyURL(userID, urlID); err != nil {
+		return nil, err
+	}
+
+	monitor, err := s.findMonitor(urlID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.IntervalSeconds != nil {
+		if *update.IntervalSeconds < minMonitorInterval || *update.IntervalSeconds > maxMonitorInterval {
+			return nil, fmt.Errorf("invalid interval: must be between %d and %d seconds", minMonitorInterval, maxMonitorInterval)
+		}
+		monitor.IntervalSeconds = *update.IntervalSeconds
+	}
+	if update.Enabled != nil {
+		monitor.Enabled = *update.Enabled
+	}
+
+	if err := s.db.Save(monitor).Error; err != nil {
+		return nil, fmt.Errorf("failed to save monitor: %w", err)
+	}
+
+	return monitor, nil
+}
+
+// GetUptime reports the uptime, response times and incidents of a URL for each window.
+// Counts and response times are aggregated by the database; only the checks that start or
+// end an incident are loaded.
+func (s *MonitorService) GetUptime(userID, urlID uint) (*UptimeReport, error) {
+	if err := s.verifyURL(userID, urlID); err != nil {
+		return nil, err
+	}
+
+	monitor, err := s.findMonitor(urlID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &UptimeReport{
+		Monitor: monitor,
+		Windows: make(map[string]crawler.UptimeSummary, len(uptimeWindows)),
+	}
+
+	var lastCheck models.UptimeCheck
+	err = s.db.Where("url_id = ?", urlID).Order("checked_at DESC, id DESC").First(&lastCheck).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("failed to retrieve uptime checks: %w", err)
+	}
+	if err == nil {
+		report.LastCheck = &lastCheck
+	}
+
+	now := time.Now()
+	transitions, err := s.incidentChecks(urlID, now.Add(-uptimeRetention), now)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := time.Duration(monitor.IntervalSeconds) * time.Second
+	for _, window := range uptimeWindows {
+		since := now.Add(-window.Duration)
+		totals, err := s.uptimeTotals(urlID, since, now)
+		if err != nil {
+			return nil, err
+		}
+		report.Windows[window.Name] = totals.Summary(crawler.UptimeIncidents(transitions, since, now, interval))
+	}
+
+	return report, nil
+}
+
+// uptimeTotals counts the checks of a URL in a window and aggregates their response times
+func (s *MonitorService) uptimeTotals(urlID uint, since, until time.Time) (crawler.UptimeTotals, error) {
+	var totals crawler.UptimeTotals
+	window := s.db.Model(&models.UptimeCheck{}).Where("url_id = ? AND checked_at BETWEEN ? AND ?", urlID, since, until)
+
+	err := window.Session(&gorm.Session{}).
+		Select("COUNT(*) AS checks, " +
+			"COALESCE(SUM(up), 0) AS up_checks, " +
+			"COUNT(CASE WHEN status_code > 0 THEN 1 END) AS responses, " +
+			"COALESCE(SUM(CASE WHEN status_code > 0 THEN response_time_ms END), 0) AS total_response_ms, " +
+			"COALESCE(MAX(CASE WHEN status_code > 0 THEN response_time_ms END), 0) AS max_response_ms").
+		Scan(&totals).Error
+	if err != nil {
+		return totals, fmt.Errorf("failed to aggregate uptime checks: %w", err)
+	}
+
+	if totals.Responses > 0 {
+		var p95 []int64
+		err := window.Session(&gorm.Session{}).
+			Where("status_code > 0").
+			Order("response_time_ms ASC").
+			Offset(crawler.P95Index(totals.Responses)).
+			Limit(1).
+			Pluck("response_time_ms", &p95).Error
+		if err != nil {
+			return totals, fmt.Errorf("failed to aggregate uptime checks: %w", err)
+		}
+		if len(p95) > 0 {
+			totals.P95ResponseMs = p95[0]
+		}
+	}
+
+	return totals, nil
+}
+
+// incidentChecks loads the failed checks of a URL and the first successful check after
+// each, which is all incidents are made of
+func (s *MonitorService) incidentChecks(urlID uint, since, until time.Time) ([]crawler.ProbeResult, error) {
+	ordered := s.db.Model(&models.UptimeCheck{}).
+		Select("uptime_checks.*, LAG(up) OVER (ORDER BY checked_at, id) AS previous_up").
+		Where("url_id = ? AND checked_at BETWEEN ? AND ?", urlID, since, until)
+
+	var checks []models.UptimeCheck
+	err := s.db.Table("(?) AS transitions", ordered).
+		Where("up = ? OR previous_up = ?", false, false).
+		Order("checked_at ASC, id ASC").
+		Find(&checks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve uptime checks: %w", err)
+	}
+
+	probes := make([]crawler.ProbeResult, 0, len(checks))
+	for _, check := range checks {
+		probes = append(probes, crawler.ProbeResult{
+			CheckedAt:    check.CheckedAt,
+			StatusCode:   check.StatusCode,
+			ResponseTime: time.Duration(check.ResponseTimeMs) * time.Millisecond,
+			Up:           check.Up,
+			Error:        check.Error,
+		})
+	}
+	return probes, nil
+}
+
+// GetChecks returns the most recent probes of a URL, oldest first, for charting
+func (s *MonitorService) GetChecks(userID, urlID uint, limit int) ([]models.UptimeCheck, error) {
+	if err := s.verifyURL(userID, urlID); err != nil {
+		return nil, err
+	}
+	if limit < 1 {
+		limit = defaultUptimeChecks
+	}
+	if limit > maxUptimeChecks {
+		limit = maxUptimeChecks
+	}
+
+	checks := []models.UptimeCheck{}
+	if err := s.db.Where("url_id = ?", urlID).
+		Order("checked_at DESC, id DESC").
+		Limit(limit).
+		Find(&checks).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve uptime checks: %w", err)
+	}
+
+	// Reverse to chronological order
+	for i, j := 0, len(checks)-1; i < j; i, j = i+1, j-1 {
+		checks[i], checks[j] = checks[j], checks[i]
+	}
+	return checks, nil
+}
+
+// verifyURL checks that the URL belongs to the user
+func (s *MonitorService) verifyURL(userID, urlID uint) error {
+	var count int64
+	if err := s.db.Model(&models.URL{}).Where("id = ? AND user_id = ?", urlID, userID).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to verify URL: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("URL not found")
+	}
+	return nil
+}
+
+// findMonitor loads the monitor of a URL, falling back to the defaults
+func (s *MonitorService) findMonitor(urlID, userID uint) (*models.Monitor, error) {
+	monitor := models.Monitor{UserID: userID, URLID: urlID, IntervalSeconds: defaultMonitorInterval}
+	err := s.db.Where("url_id = ?", urlID).First(&monitor).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("failed to load monitor: %w", err)
+	}
+	return &monitor, nil
+}
+
+// run probes due monitors on every tick and prunes checks older than the longest window
+func (s *MonitorService) run(ctx context.Context) {
+	ticker := time.NewTicker(monitorTick)
+	defer ticker.Stop()
+
+	var lastPrune time.Time
+	for {
+		s.probeDue(ctx)
+		if time.Since(lastPrune) >= uptimePruneInterval {
+			s.prune()
+			lastPrune = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probeDue starts a probe for every enabled monitor whose interval has elapsed
+func (s *MonitorService) probeDue(ctx context.Context) {
+	var monitors []struct {
+		ID              uint
+		URLID           uint
+		URL             string
+		IntervalSeconds int
+		LastCheckedAt   *time.Time
+	}
+	err := s.db.Table("monitors").
+		Select("monitors.id, monitors.url_id, urls.url, monitors.interval_seconds, monitors.last_checked_at").
+		Joins("JOIN urls ON urls.id = monitors.url_id AND urls.deleted_at IS NULL").
+		Where("monitors.enabled = ?", true).
+		Scan(&monitors).Error
+	if err != nil {
+		log.Printf("Failed to load monitors: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, monitor := range monitors {
+		interval := time.Duration(monitor.IntervalSeconds) * time.Second
+		if monitor.LastCheckedAt != nil && now.Sub(*monitor.LastCheckedAt) < interval {
+			continue
+		}
+
+		// Skip URLs whose previous probe hasn't finished
+		s.mu.Lock()
+		if s.inFlight[monitor.URLID] || len(s.inFlight) >= maxConcurrentProbes {
+			s.mu.Unlock()
+			continue
+		}
+		s.inFlight[monitor.URLID] = true
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func(monitorID, urlID uint, targetURL string) {
+			defer func() {
+				s.mu.Lock()
+				delete(s.inFlight, urlID)
+				s.mu.Unlock()
+				s.wg.Done()
+			}()
+			s.probe(ctx, monitorID, urlID, targetURL)
+		}(monitor.ID, monitor.URLID, monitor.URL)
+	}
+}
+
+// probe fetches a URL and records the outcome in its uptime time series
+func (s *MonitorService) probe(ctx context.Context, monitorID, urlID uint, targetURL string) {
+	result := s.crawlerService.Probe(ctx, targetURL)
+	if ctx.Err() != nil {
+		// Shutting down: the probe was cut short and says nothing about the page
+		return
+	}
+
+	check := models.UptimeCheck{
+		URLID:          urlID,
+		CheckedAt:      result.CheckedAt,
+		StatusCode:     result.StatusCode,
+		ResponseTimeMs: result.ResponseTime.Milliseconds(),
+		Up:             result.Up,
+		Error:          result.Error,
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&check).Error; err != nil {
+			return fmt.Errorf("failed to save uptime check: %w", err)
+		}
+		if err := tx.Model(&models.Monitor{}).Where("id = ?", monitorID).Update("last_checked_at", result.CheckedAt).Error; err != nil {
+			return fmt.Errorf("failed to update monitor: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to record probe of URL %d: %v", urlID, err)
+		return
+	}
+
+	if !result.Up {
+		log.Printf("Uptime probe of URL %d (%s) failed: %s", urlID, targetURL, result.Error)
+	}
+}
+
+// prune deletes checks older than the longest reported window
+func (s *MonitorService) prune() {
+	result := s.db.Where("checked_at < ?", time.Now().Add(-uptimeRetention)).Delete(&models.UptimeCheck{})
+	if result.Error != nil {
+		log.Printf("Failed to prune uptime checks: %v", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("Pruned %d uptime checks", result.RowsAffected)
+	}
+}
@@ -7,10 +7,42 @@ import (
 
 	"web-crawler-dashboard/internal/crawler"
 	"web-crawler-dashboard/internal/models"
+	"web-crawler-dashboard/internal/seo"
 
 	"gorm.io/gorm"
 )
 
+// SEORuleStatus describes an SEO rule and whether it is enabled for a URL
+type SEORuleStatus struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Weight      int    `json:"weight"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// TechnologyUsage counts the user's analyzed pages using a technology version
+type TechnologyUsage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Pages   int64  `json:"pages"`
+}
+
+// ThirdPartyUsage counts the user's analyzed pages using a third-party domain
+type ThirdPartyUsage struct {
+	Domain             string `json:"domain"`
+	Company            string `json:"company"`
+	Category           string `json:"category"`
+	Pages              int64  `json:"pages"`
+	PagesBeforeConsent int64  `json:"pages_before_consent"` // Pages loading it before the consent banner
+}
+
+// CrawlSettingsUpdate holds the crawl settings to change; nil fields are left unchanged
+type CrawlSettingsUpdate struct {
+	DetectSoft404  *bool
+	CountedClasses []string
+}
+
 // URLService provides business logic for URL management and crawling
 type URLService struct {
 	db             *gorm.DB
@@ -26,7 +58,7 @@ func NewURLService(db *gorm.DB) *URLService {
 	return &URLService{
 		db:             db,
 		crawlerService: crawler.NewCrawlerService(crawlerConfig),
-		linkAnalyzer:   crawler.NewLinkAnalyzer(crawlerConfig),
+		linkAnalyzer:   crawler.NewLinkAnalyzer(crawlerConfig, nil, nil),
 	}
 }
 
@@ -125,8 +157,9 @@ func (s *URLService) DeleteURL(userID, urlID uint) error {
 	return nil
 }
 
-// StartAnalysis starts crawling analysis for a URL
-func (s *URLService) StartAnalysis(ctx context.Context, userID, urlID uint) error {
+// StartAnalysis starts crawling analysis for a URL.
+// With fresh set, every link is checked again instead of using cached outcomes.
+func (s *URLService) StartAnalysis(ctx context.Context, userID, urlID uint, fresh bool) error {
 	// Get and validate URL
 	url, err := s.GetURL(userID, urlID)
 	if err != nil {
@@ -151,7 +184,9 @@ func (s *URLService) StartAnalysis(ctx context.Context, userID, urlID uint) erro
 
 	// Start crawling asynchronously
 	// Use background context instead of request context since this is async operation
-	err = s.crawlerService.CrawlAsync(context.Background(), urlID, url.URL, func(result *crawler.CrawlResult) {
+	options := s.loadCrawlOptions(s.db, userID, urlID)
+	options.FreshLinkCheck = fresh
+	err = s.crawlerService.CrawlAsync(context.Background(), urlID, url.URL, options, func(result *crawler.CrawlResult) {
 		s.handleCrawlResult(urlID, result)
 	})
 
@@ -196,7 +231,7 @@ func (s *URLService) StopAnalysis(userID, urlID uint) error {
 }
 
 // ReRunAnalysis re-runs analysis for a URL that has already been analyzed
-func (s *URLService) ReRunAnalysis(ctx context.Context, userID, urlID uint) error {
+func (s *URLService) ReRunAnalysis(ctx context.Context, userID, urlID uint, fresh bool) error {
 	// Get and validate URL
 	url, err := s.GetURL(userID, urlID)
 	if err != nil {
@@ -230,7 +265,7 @@ func (s *URLService) ReRunAnalysis(ctx context.Context, userID, urlID uint) erro
 	}
 
 	// Start new analysis
-	err = s.StartAnalysis(ctx, userID, urlID)
+	err = s.StartAnalysis(ctx, userID, urlID, fresh)
 	if err != nil {
 		return fmt.Errorf("failed to start re-analysis: %w", err)
 	}
@@ -249,7 +284,31 @@ func (s *URLService) GetAnalysisResult(userID, urlID uint) (*models.AnalysisResu
 
 	// Get analysis result
 	var analysis models.AnalysisResult
-	result := s.db.Where("url_id = ?", urlID).Preload("BrokenLinksDetails").First(&analysis)
+	result := s.db.Where("url_id = ?", urlID).Preload("BrokenLinksDetails").
+		Preload("HreflangLinks").
+		Preload("IndexingIssues").
+		Preload("SEOFindings").
+		Preload("Headings", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("HeadingIssues").
+		Preload("Images").
+		Preload("ImageIssues").
+		Preload("LinkIssues").
+		Preload("Technologies").
+		Preload("ThirdParties").
+		Preload("Forms", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("FormIssues").
+		Preload("AnalyzerMetrics").
+		Preload("AnalyzerFindings").
+		Preload("Extractions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name ASC")
+		}).
+		Preload("MixedContent").
+		Preload("SkippedLinks").
+		First(&analysis)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("no analysis results found for this URL")
@@ -260,6 +319,169 @@ func (s *URLService) GetAnalysisResult(userID, urlID uint) (*models.AnalysisResu
 	return &analysis, nil
 }
 
+// GetSEORules returns every SEO rule with its enabled state for a URL
+func (s *URLService) GetSEORules(userID, urlID uint) ([]SEORuleStatus, error) {
+	// First verify user owns the URL
+	if _, err := s.GetURL(userID, urlID); err != nil {
+		return nil, err
+	}
+
+	config := s.loadSEOConfig(s.db, urlID)
+
+	var rules []SEORuleStatus
+	for _, rule := range seo.DefaultRules() {
+		rules = append(rules, SEORuleStatus{
+			ID:          rule.ID,
+			Name:        rule.Name,
+			Description: rule.Description,
+			Weight:      rule.Weight,
+			Enabled:     config.IsEnabled(rule.ID),
+		})
+	}
+
+	return rules, nil
+}
+
+// UpdateSEORules enables or disables SEO rules for a URL
+func (s *URLService) UpdateSEORules(userID, urlID uint, rules map[string]bool) error {
+	// First verify user owns the URL
+	if _, err := s.GetURL(userID, urlID); err != nil {
+		return err
+	}
+
+	for ruleID := range rules {
+		if _, exists := seo.FindRule(ruleID); !exists {
+			return fmt.Errorf("unknown SEO rule: %s", ruleID)
+		}
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		for ruleID, enabled := range rules {
+			var setting models.SEORuleSetting
+			err := tx.Where("url_id = ? AND rule_id = ?", urlID, ruleID).First(&setting).Error
+			if err != nil && err != gorm.ErrRecordNotFound {
+				return fmt.Errorf("failed to load SEO rule setting: %w", err)
+			}
+
+			setting.URLID = urlID
+			setting.RuleID = ruleID
+			setting.Enabled = enabled
+			if err := tx.Save(&setting).Error; err != nil {
+				return fmt.Errorf("failed to save SEO rule setting: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// loadSEOConfig builds the SEO audit configuration for a URL
+func (s *URLService) loadSEOConfig(db *gorm.DB, urlID uint) *seo.Config {
+	var disabled []string
+	if err := db.Model(&models.SEORuleSetting{}).
+		Where("url_id = ? AND enabled = ?", urlID, false).
+		Pluck("rule_id", &disabled).Error; err != nil {
+		log.Printf("Warning: failed to load SEO rule settings for URL ID %d: %v", urlID, err)
+	}
+	return seo.NewConfig(disabled)
+}
+
+// GetCrawlSettings returns the crawl settings for a URL
+func (s *URLService) GetCrawlSettings(userID, urlID uint) (*models.CrawlSettings, error) {
+	// First verify user owns the URL
+	if _, err := s.GetURL(userID, urlID); err != nil {
+		return nil, err
+	}
+
+	return s.findCrawlSettings(s.db, urlID)
+}
+
+// UpdateCrawlSettings changes the crawl settings for a URL
+func (s *URLService) UpdateCrawlSettings(userID, urlID uint, update CrawlSettingsUpdate) (*models.CrawlSettings, error) {
+	// First verify user owns the URL
+	if _, err := s.GetURL(userID, urlID); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.findCrawlSettings(s.db, urlID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.DetectSoft404 != nil {
+		settings.DetectSoft404 = *update.DetectSoft404
+	}
+	if update.CountedClasses != nil {
+		for _, class := range update.CountedClasses {
+			if !crawler.IsValidLinkClass(class) {
+				return nil, fmt.Errorf("invalid link class: %s", class)
+			}
+		}
+		settings.CountedClasses = update.CountedClasses
+	}
+
+	if err := s.db.Save(settings).Error; err != nil {
+		return nil, fmt.Errorf("failed to save crawl settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// findCrawlSettings loads the crawl settings for a URL, falling back to the defaults
+func (s *URLService) findCrawlSettings(db *gorm.DB, urlID uint) (*models.CrawlSettings, error) {
+	settings := models.CrawlSettings{URLID: urlID}
+	err := db.Where("url_id = ?", urlID).First(&settings).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("failed to load crawl settings: %w", err)
+	}
+	if settings.CountedClasses == nil {
+		settings.CountedClasses = crawler.DefaultCountedClasses()
+	}
+	return &settings, nil
+}
+
+// loadCrawlOptions builds the crawler options for a URL
+func (s *URLService) loadCrawlOptions(db *gorm.DB, userID, urlID uint) *crawler.CrawlOptions {
+	options := &crawler.CrawlOptions{}
+
+	settings, err := s.findCrawlSettings(db, urlID)
+	if err != nil {
+		log.Printf("Warning: failed to load crawl settings for URL ID %d: %v", urlID, err)
+	} else {
+		options.DetectSoft404 = settings.DetectSoft404
+		options.CountedClasses = settings.CountedClasses
+	}
+
+	disabledAnalyzers, err := loadDisabledAnalyzers(db, userID)
+	if err != nil {
+		log.Printf("Warning: failed to load analyzer settings for user ID %d: %v", userID, err)
+	} else {
+		options.DisabledAnalyzers = disabledAnalyzers
+	}
+
+	extractionRules, err := loadExtractionRules(db, userID, urlID)
+	if err != nil {
+		log.Printf("Warning: failed to load extraction rules for URL ID %d: %v", urlID, err)
+	} else {
+		options.ExtractionRules = extractionRules
+	}
+
+	assertions, err := loadAssertions(db, urlID)
+	if err != nil {
+		log.Printf("Warning: failed to load assertions for URL ID %d: %v", urlID, err)
+	} else {
+		options.Assertions = assertions
+	}
+
+	linkFilter, err := loadLinkFilter(db, userID, urlID)
+	if err != nil {
+		log.Printf("Warning: failed to load link filter rules for URL ID %d: %v", urlID, err)
+	} else {
+		options.LinkFilter = linkFilter
+	}
+
+	return options
+}
+
 // handleCrawlResult processes the result of a crawl operation
 func (s *URLService) handleCrawlResult(urlID uint, result *crawler.CrawlResult) {
 	// Start a transaction
@@ -277,10 +499,20 @@ func (s *URLService) handleCrawlResult(urlID uint, result *crawler.CrawlResult)
 		return
 	}
 
+	// Evaluate the URL's assertions for this run
+	var assertionRun *models.AssertionRun
+	if len(result.Assertions) > 0 {
+		assertionRun = s.crawlerService.ConvertToAssertionRun(result, urlID)
+	}
+
 	// Update URL status based on result
 	if result.Error != "" {
 		url.Status = models.StatusError
 		url.Title = result.Error // Store error message in title temporarily
+	} else if assertionRun != nil && !assertionRun.Passed {
+		url.Status = models.StatusAssertionFailed
+		url.Title = result.Title
+		log.Printf("URL ID %d failed %d of %d assertions", urlID, assertionRun.Failed, assertionRun.Total)
 	} else {
 		url.Status = models.StatusCompleted
 		url.Title = result.Title
@@ -293,10 +525,26 @@ func (s *URLService) handleCrawlResult(urlID uint, result *crawler.CrawlResult)
 		return
 	}
 
+	// Save the assertion run; runs are kept across analyses
+	if assertionRun != nil {
+		if err := tx.Create(assertionRun).Error; err != nil {
+			tx.Rollback()
+			log.Printf("Failed to save assertion run for URL %d: %v", urlID, err)
+			return
+		}
+	}
+
 	// Create analysis result
 	analysisResult := s.crawlerService.ConvertToAnalysisResult(result, urlID)
 	analysisResult.URLID = urlID
 
+	// Run the on-page SEO audit with the URL's rule settings
+	var seoReport *seo.Report
+	if result.ParseResult != nil {
+		seoReport = seo.Audit(result.ParseResult, s.loadSEOConfig(tx, urlID))
+		analysisResult.SEOScore = seoReport.Score
+	}
+
 	// Save analysis result
 	if err := tx.Create(analysisResult).Error; err != nil {
 		tx.Rollback()
@@ -314,6 +562,186 @@ func (s *URLService) handleCrawlResult(urlID uint, result *crawler.CrawlResult)
 		}
 	}
 
+	// Save skipped links if any
+	if len(result.SkippedLinks) > 0 {
+		skippedLinks := s.crawlerService.ConvertToSkippedLinks(result, analysisResult.ID)
+		if err := tx.Create(&skippedLinks).Error; err != nil {
+			tx.Rollback()
+			log.Printf("Failed to save skipped links for URL %d: %v", urlID, err)
+			return
+		}
+	}
+
+	// Save link graph edges if any
+	if len(result.Links) > 0 {
+		linkEdges := s.crawlerService.ConvertToLinkEdges(result, analysisResult.ID, urlID)
+		if err := tx.CreateInBatches(&linkEdges, 500).Error; err != nil {
+			tx.Rollback()
+			log.Printf("Failed to save link edges for URL %d: %v", urlID, err)
+			return
+		}
+	}
+
+	// Save link issues if any
+	if len(result.LinkIssues) > 0 {
+		linkIssues := s.crawlerService.ConvertToLinkIssues(result, analysisResult.ID)
+		if err := tx.Create(&linkIssues).Error; err != nil {
+			tx.Rollback()
+			log.Printf("Failed to save link issues for URL %d: %v", urlID, err)
+			return
+		}
+	}
+
+	// Save detected technologies if any
+	if len(result.Technologies) > 0 {
+		technologies := s.crawlerService.ConvertToTechnologies(result, analysisResult.ID)
+		if err := tx.Create(&technologies).Error; err != nil {
+			tx.Rollback()
+			log.Printf("Failed to save technologies for URL %d: %v", urlID, err)
+			return
+		}
+	}
+
+	// Save the third-party inventory if any
+	if len(result.Privacy.ThirdParties) > 0 {
+		thirdParties := s.crawlerService.ConvertToThirdParties(result, analysisResult.ID)
+		if err := tx.Create(&thirdParties).Error; err != nil {
+			tx.Rollback()
+			log.Printf("Failed to save third parties for URL %d: %v", urlID, err)
+			return
+		}
+	}
+
+	// Save the form inventory and form issues if any
+	if len(result.Forms) > 0 {
+		forms, formIssues := s.crawlerService.ConvertToForms(result, analysisResult.ID)
+		if err := tx.Create(&forms).Error; err != nil {
+			tx.Rollback()
+			log.Printf("Failed to save forms for URL %d: %v", urlID, err)
+			return
+		}
+		if len(formIssues) > 0 {
+			if err := tx.Create(&formIssues).Error; err != nil {
+				tx.Rollback()
+				log.Printf("Failed to save form issues for URL %d: %v", urlID, err)
+				return
+			}
+		}
+	}
+
+	// Save page analyzer metrics and findings if any
+	if len(result.Analyzers) > 0 {
+		analyzerMetrics, analyzerFindings := s.crawlerService.ConvertToAnalyzerResults(result, analysisResult.ID)
+		if len(analyzerMetrics) > 0 {
+			if err := tx.Create(&analyzerMetrics).Error; err != nil {
+				tx.Rollback()
+				log.Printf("Failed to save analyzer metrics for URL %d: %v", urlID, err)
+				return
+			}
+		}
+		if len(analyzerFindings) > 0 {
+			if err := tx.Create(&analyzerFindings).Error; err != nil {
+				tx.Rollback()
+				log.Printf("Failed to save analyzer findings for URL %d: %v", urlID, err)
+				return
+			}
+		}
+	}
+
+	// Save extracted values if any
+	if len(result.Extractions) > 0 {
+		extractions := s.crawlerService.ConvertToExtractions(result, analysisResult.ID)
+		if err := tx.Create(&extractions).Error; err != nil {
+			tx.Rollback()
+			log.Printf("Failed to save extracted values for URL %d: %v", urlID, err)
+			return
+		}
+	}
+
+	// Save hreflang links if any
+	if len(result.Hreflangs) > 0 {
+		hreflangLinks := s.crawlerService.ConvertToHreflangLinks(result, analysisResult.ID)
+		if err := tx.Create(&hreflangLinks).Error; err != nil {
+			tx.Rollback()
+			log.Printf("Failed to save hreflang links for URL %d: %v", urlID, err)
+			return
+		}
+	}
+
+	// Save indexing issues if any
+	if len(result.IndexingIssues) > 0 {
+		indexingIssues := s.crawlerService.ConvertToIndexingIssues(result, analysisResult.ID)
+		if err := tx.Create(&indexingIssues).Error; err != nil {
+			tx.Rollback()
+			log.Printf("Failed to save indexing issues for URL %d: %v", urlID, err)
+			return
+		}
+	}
+
+	// Save heading outline if any
+	if len(result.Outline.Headings) > 0 {
+		headings, headingIssues := s.crawlerService.ConvertToHeadings(result, analysisResult.ID)
+		if err := tx.Create(&headings).Error; err != nil {
+			tx.Rollback()
+			log.Printf("Failed to save heading outline for URL %d: %v", urlID, err)
+			return
+		}
+		if len(headingIssues) > 0 {
+			if err := tx.Create(&headingIssues).Error; err != nil {
+				tx.Rollback()
+				log.Printf("Failed to save heading issues for URL %d: %v", urlID, err)
+				return
+			}
+		}
+	}
+
+	// Save image inventory if any
+	if len(result.Images) > 0 || len(result.ImageIssues) > 0 {
+		images, imageIssues := s.crawlerService.ConvertToImages(result, analysisResult.ID)
+		if len(images) > 0 {
+			if err := tx.Create(&images).Error; err != nil {
+				tx.Rollback()
+				log.Printf("Failed to save images for URL %d: %v", urlID, err)
+				return
+			}
+		}
+		if len(imageIssues) > 0 {
+			if err := tx.Create(&imageIssues).Error; err != nil {
+				tx.Rollback()
+				log.Printf("Failed to save image issues for URL %d: %v", urlID, err)
+				return
+			}
+		}
+	}
+
+	// Save mixed content if any
+	if len(result.MixedContent) > 0 {
+		mixedContent := s.crawlerService.ConvertToMixedContent(result, analysisResult.ID)
+		if err := tx.Create(&mixedContent).Error; err != nil {
+			tx.Rollback()
+			log.Printf("Failed to save mixed content for URL %d: %v", urlID, err)
+			return
+		}
+	}
+
+	// Save SEO findings if any
+	if seoReport != nil && len(seoReport.Findings) > 0 {
+		var findings []models.SEOFinding
+		for _, finding := range seoReport.Findings {
+			findings = append(findings, models.SEOFinding{
+				AnalysisID: analysisResult.ID,
+				RuleID:     finding.RuleID,
+				Severity:   string(finding.Severity),
+				Message:    finding.Message,
+			})
+		}
+		if err := tx.Create(&findings).Error; err != nil {
+			tx.Rollback()
+			log.Printf("Failed to save SEO findings for URL %d: %v", urlID, err)
+			return
+		}
+	}
+
 	// Commit transaction
 	if err := tx.Commit().Error; err != nil {
 		tx.Rollback()
@@ -324,6 +752,82 @@ func (s *URLService) handleCrawlResult(urlID uint, result *crawler.CrawlResult)
 	log.Printf("Successfully processed crawl result for URL ID %d", urlID)
 }
 
+// GetTechnologyUsage returns the technologies detected across the user's analyzed pages
+func (s *URLService) GetTechnologyUsage(userID uint) ([]TechnologyUsage, error) {
+	usage := []TechnologyUsage{}
+	err := s.db.Table("technologies").
+		Select("technologies.name, technologies.version, COUNT(DISTINCT analysis_results.url_id) AS pages").
+		Joins("JOIN analysis_results ON analysis_results.id = technologies.analysis_id AND analysis_results.deleted_at IS NULL").
+		Joins("JOIN urls ON urls.id = analysis_results.url_id").
+		Where("urls.user_id = ? AND urls.deleted_at IS NULL", userID).
+		Group("technologies.name, technologies.version").
+		Order("pages DESC, technologies.name ASC, technologies.version ASC").
+		Scan(&usage).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve technology usage: %w", err)
+	}
+
+	return usage, nil
+}
+
+// GetTrackerInventory returns the third-party domains used across the user's analyzed pages,
+// optionally limited to one category, with how many pages load them before consent
+func (s *URLService) GetTrackerInventory(userID uint, category string) ([]ThirdPartyUsage, error) {
+	usage := []ThirdPartyUsage{}
+	query := s.db.Table("third_parties").
+		Select("third_parties.domain, third_parties.company, third_parties.category, " +
+			"COUNT(DISTINCT analysis_results.url_id) AS pages, " +
+			"COUNT(DISTINCT CASE WHEN third_parties.before_consent THEN analysis_results.url_id END) AS pages_before_consent").
+		Joins("JOIN analysis_results ON analysis_results.id = third_parties.analysis_id AND analysis_results.deleted_at IS NULL").
+		Joins("JOIN urls ON urls.id = analysis_results.url_id").
+		Where("urls.user_id = ? AND urls.deleted_at IS NULL", userID)
+	switch category {
+	case "":
+	case crawler.CategoryAdvertising, crawler.CategoryAnalytics, crawler.CategorySocial,
+		crawler.CategoryCDN, crawler.CategoryConsent, crawler.CategoryOther:
+		query = query.Where("third_parties.category = ?", category)
+	default:
+		return nil, fmt.Errorf("invalid category: %s", category)
+	}
+
+	err := query.
+		Group("third_parties.domain, third_parties.company, third_parties.category").
+		Order("pages_before_consent DESC, pages DESC, third_parties.domain ASC").
+		Scan(&usage).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve third-party inventory: %w", err)
+	}
+
+	return usage, nil
+}
+
+// CrawlerService returns the crawler whose host scheduler and circuit breaker all crawls share
+func (s *URLService) CrawlerService() *crawler.CrawlerService {
+	return s.crawlerService
+}
+
+// GetCrawlerMetrics returns the shared crawler state, with circuit breakers and throttled
+// hosts limited to the hosts of the user's URLs and of the links found broken on them
+func (s *URLService) GetCrawlerMetrics(userID uint) (crawler.CrawlerMetrics, error) {
+	var urls []string
+	if err := s.db.Model(&models.URL{}).Where("user_id = ?", userID).Pluck("url", &urls).Error; err != nil {
+		return crawler.CrawlerMetrics{}, fmt.Errorf("failed to load URLs: %w", err)
+	}
+
+	var brokenURLs []string
+	err := s.db.Model(&models.BrokenLink{}).
+		Joins("JOIN analysis_results ON analysis_results.id = broken_links.analysis_id").
+		Joins("JOIN urls ON urls.id = analysis_results.url_id AND urls.deleted_at IS NULL").
+		Where("urls.user_id = ?", userID).
+		Distinct().
+		Pluck("broken_links.url", &brokenURLs).Error
+	if err != nil {
+		return crawler.CrawlerMetrics{}, fmt.Errorf("failed to load broken links: %w", err)
+	}
+
+	return s.crawlerService.MetricsForURLs(append(urls, brokenURLs...)), nil
+}
+
 // GetRunningAnalyses returns the count of currently running analyses for a user
 func (s *URLService) GetRunningAnalyses(userID uint) (int64, error) {
 	var count int64
@@ -361,7 +865,7 @@ func (s *URLService) GetUserStats(userID uint) (map[string]interface{}, error) {
 	}
 
 	stats["status_counts"] = statusCounts
-	stats["total_urls"] = statusCounts["queued"] + statusCounts["processing"] + statusCounts["completed"] + statusCounts["error"]
+	stats["total_urls"] = statusCounts["queued"] + statusCounts["processing"] + statusCounts["completed"] + statusCounts["error"] + statusCounts["assertion_failed"]
 
 	// Get recent activity (last 10 URLs)
 	var recentURLs []models.URL
@@ -389,10 +893,11 @@ func (s *URLService) saveBrokenLinksDetails(analysisID uint, brokenLinks []crawl
 	var dbBrokenLinks []models.BrokenLink
 	for _, link := range brokenLinks {
 		dbBrokenLinks = append(dbBrokenLinks, models.BrokenLink{
-			AnalysisID: analysisID,
-			URL:        link.URL,
-			StatusCode: link.StatusCode,
-			Error:      link.Error,
+			AnalysisID:   analysisID,
+			URL:          link.URL,
+			StatusCode:   link.StatusCode,
+			Error:        link.Error,
+			ResourceType: link.ResourceType,
 		})
 	}
 
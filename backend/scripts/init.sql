@@ -15,7 +15,7 @@ CREATE TABLE IF NOT EXISTS urls (
     user_id BIGINT UNSIGNED NOT NULL,
     url VARCHAR(2048) NOT NULL,
     title VARCHAR(255),
-    status ENUM('queued', 'pending', 'processing', 'completed', 'error') DEFAULT 'queued',
+    status ENUM('queued', 'pending', 'processing', 'completed', 'error', 'assertion_failed') DEFAULT 'queued',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
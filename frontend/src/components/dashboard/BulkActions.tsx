@@ -56,7 +56,8 @@ export function BulkActions({
     (urlsByStatus["unknown"] || 0);
 
   const processingCount = urlsByStatus["processing"] || 0;
-  const completedCount = urlsByStatus["completed"] || 0;
+  const completedCount =
+    (urlsByStatus["completed"] || 0) + (urlsByStatus["assertion_failed"] || 0);
   const errorCount = urlsByStatus["error"] || 0;
 
   // Determine deletable URLs (not processing)
@@ -132,8 +133,7 @@ export function BulkActions({
     if (!canRerunAnalysis) return "No URLs to re-run";
 
     const parts = [];
-    if (urlsByStatus["completed"])
-      parts.push(`${urlsByStatus["completed"]} completed`);
+    if (completedCount) parts.push(`${completedCount} completed`);
     if (urlsByStatus["error"]) parts.push(`${urlsByStatus["error"]} failed`);
 
     return `Re-run Analysis (${parts.join(", ")})`;
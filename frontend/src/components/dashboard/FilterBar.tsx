@@ -103,6 +103,7 @@ export const FilterBar = memo(function FilterBar({
     { value: "pending", label: "Pending" },
     { value: "processing", label: "Processing" },
     { value: "completed", label: "Completed" },
+    { value: "assertion_failed", label: "Assertion Failed" },
     { value: "error", label: "Error" },
   ];
 
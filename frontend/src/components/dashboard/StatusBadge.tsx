@@ -12,6 +12,7 @@ const STATUS_COLORS = {
   completed: "bg-green-100 text-green-800 border-green-200",
   success: "bg-green-100 text-green-800 border-green-200",
   error: "bg-red-100 text-red-800 border-red-200",
+  assertion_failed: "bg-orange-100 text-orange-800 border-orange-200",
 };
 
 export function StatusBadge({ status, className }: StatusBadgeProps) {
@@ -49,6 +50,12 @@ export function StatusBadge({ status, className }: StatusBadgeProps) {
           color: STATUS_COLORS.error,
           text: "Error",
         };
+      case "assertion_failed":
+        return {
+          variant: "destructive" as const,
+          color: STATUS_COLORS.assertion_failed,
+          text: "Assertion Failed",
+        };
       case "unknown":
         return {
           variant: "outline" as const,
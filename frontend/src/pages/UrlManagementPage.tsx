@@ -274,7 +274,9 @@ export function UrlManagementPage() {
                   const rerunIds = selectedUrlObjects
                     .filter(
                       (url) =>
-                        url.status === "completed" || url.status === "error"
+                        url.status === "completed" ||
+                        url.status === "assertion_failed" ||
+                        url.status === "error"
                     )
                     .map((url) => url.id);
                   return bulkRerun(rerunIds);
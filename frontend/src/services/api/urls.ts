@@ -212,7 +212,7 @@ export const urlsApi = {
 
   // Helper function to check if URL is completed
   isCompleted: (url: Url): boolean => {
-    return url.status === "completed";
+    return url.status === "completed" || url.status === "assertion_failed";
   },
 
   // Helper function to check if URL has error
@@ -231,6 +231,8 @@ export const urlsApi = {
         return "Completed";
       case "error":
         return "Error";
+      case "assertion_failed":
+        return "Assertion Failed";
       default:
         return "Unknown";
     }
@@ -247,6 +249,8 @@ export const urlsApi = {
         return "green";
       case "error":
         return "red";
+      case "assertion_failed":
+        return "orange";
       default:
         return "gray";
     }
@@ -211,7 +211,8 @@ export const useUrlDataStore = create<UrlDataStore>()(
         }
 
         const completed = urls.filter(
-          (url) => url.status === "completed"
+          (url) =>
+            url.status === "completed" || url.status === "assertion_failed"
         ).length;
         const error = urls.filter((url) => url.status === "error").length;
         const analyzed = completed + error;
@@ -17,8 +17,12 @@ function getActionFromUrl(
     return "added";
   }
 
+  // Failed assertions still mean the analysis completed
+  const completed =
+    url.status === "completed" || url.status === "assertion_failed";
+
   // If recently updated and completed
-  if (url.status === "completed" && now - updated < 60 * 60 * 1000) {
+  if (completed && now - updated < 60 * 60 * 1000) {
     return "completed";
   }
 
@@ -33,7 +37,7 @@ function getActionFromUrl(
   }
 
   // Default to completed for completed URLs, added for others
-  return url.status === "completed" ? "completed" : "added";
+  return completed ? "completed" : "added";
 }
 
 // Helper function to truncate URL for toast messages
@@ -5,6 +5,7 @@ export type UrlStatus =
   | "processing"
   | "completed"
   | "error"
+  | "assertion_failed"
   | "unknown";
 
 // Main URL interface